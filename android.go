@@ -0,0 +1,139 @@
+package airship
+
+import "fmt"
+
+// AndroidOverrideWithTemplate https://docs.airship.com/api/ua/#schemas-androidoverridewithtemplate
+type AndroidOverrideWithTemplate struct {
+	Template            *TemplateRef               `json:"template,omitempty"`
+	Actions             *Actions                   `json:"actions,omitempty"`
+	Extra               map[string]string          `json:"extra,omitempty"`
+	Sound               string                     `json:"sound,omitempty"`
+	CollapseKey         string                     `json:"collapse_key,omitempty"`
+	Category            string                     `json:"category,omitempty"` // One of the AndroidCategory* constants
+	Title               string                     `json:"title,omitempty"`
+	Summary             string                     `json:"summary,omitempty"`
+	Icon                string                     `json:"icon,omitempty"`
+	IconColor           string                     `json:"icon_color,omitempty"`
+	NotificationChannel string                     `json:"notification_channel,omitempty"` // ID of a channel created by the app
+	Priority            int                        `json:"priority,omitempty"`             // Between AndroidPriorityMin and AndroidPriorityMax
+	Visibility          *int                       `json:"visibility,omitempty"`           // One of the AndroidVisibility* constants, nil for the default
+	DeliveryPriority    string                     `json:"delivery_priority,omitempty"`    // AndroidDeliveryPriorityHigh or AndroidDeliveryPriorityNormal
+	TimeToLive          *Expiry                    `json:"time_to_live,omitempty"`
+	LocalOnly           bool                       `json:"local_only,omitempty"` // Not bridged to other devices such as watches
+	Style               *AndroidStyle              `json:"style,omitempty"`
+	Wearable            *AndroidWearable           `json:"wearable,omitempty"`
+	PublicNotification  *AndroidPublicNotification `json:"public_notification,omitempty"`
+}
+
+// Values of AndroidOverrideWithTemplate.Priority.
+const (
+	AndroidPriorityMin     = -2
+	AndroidPriorityLow     = -1
+	AndroidPriorityDefault = 0
+	AndroidPriorityHigh    = 1
+	AndroidPriorityMax     = 2
+)
+
+// Values of AndroidOverrideWithTemplate.Visibility, which says what is shown on a secure lock screen.
+const (
+	AndroidVisibilitySecret  = -1 // Nothing
+	AndroidVisibilityPrivate = 0  // The PublicNotification, or the app name if there is none
+	AndroidVisibilityPublic  = 1  // The whole notification
+)
+
+// Values of AndroidOverrideWithTemplate.DeliveryPriority.
+const (
+	AndroidDeliveryPriorityHigh   = "high" // Wakes a dozing device
+	AndroidDeliveryPriorityNormal = "normal"
+)
+
+// Values of AndroidOverrideWithTemplate.Category.
+const (
+	AndroidCategoryAlarm          = "alarm"
+	AndroidCategoryCall           = "call"
+	AndroidCategoryEmail          = "email"
+	AndroidCategoryError          = "err"
+	AndroidCategoryEvent          = "event"
+	AndroidCategoryMessage        = "msg"
+	AndroidCategoryProgress       = "progress"
+	AndroidCategoryPromo          = "promo"
+	AndroidCategoryRecommendation = "recommendation"
+	AndroidCategoryService        = "service"
+	AndroidCategorySocial         = "social"
+	AndroidCategoryStatus         = "status"
+	AndroidCategorySystem         = "sys"
+	AndroidCategoryTransport      = "transport"
+)
+
+// AndroidStyle is the expanded layout of a notification. Which field holds the content depends on Type.
+// https://docs.airship.com/api/ua/#schemas-androidstyleobject
+type AndroidStyle struct {
+	Type       string   `json:"type"`                  // One of the AndroidStyle* constants
+	BigPicture string   `json:"big_picture,omitempty"` // Image URL, for AndroidStyleBigPicture
+	BigText    string   `json:"big_text,omitempty"`    // For AndroidStyleBigText
+	Lines      []string `json:"lines,omitempty"`       // For AndroidStyleInbox
+	Title      string   `json:"title,omitempty"`       // Replaces the title when expanded
+	Summary    string   `json:"summary,omitempty"`     // Replaces the summary when expanded
+}
+
+// Values of AndroidStyle.Type.
+const (
+	AndroidStyleBigPicture = "big_picture"
+	AndroidStyleBigText    = "big_text"
+	AndroidStyleInbox      = "inbox"
+)
+
+// AndroidWearable customizes the notification on Wear OS devices.
+// https://docs.airship.com/api/ua/#schemas-androidwearableobject
+type AndroidWearable struct {
+	BackgroundImage string                `json:"background_image,omitempty"`
+	ExtraPages      []AndroidWearablePage `json:"extra_pages,omitempty"`
+	Interactive     *AndroidInteractive   `json:"interactive,omitempty"`
+}
+
+// AndroidWearablePage is an extra page of a notification on a wearable.
+type AndroidWearablePage struct {
+	Title string `json:"title"`
+	Alert string `json:"alert"`
+}
+
+// AndroidInteractive adds the buttons of an interactive notification type registered by the app.
+type AndroidInteractive struct {
+	Type          string             `json:"type"`
+	ButtonActions map[string]Actions `json:"button_actions,omitempty"` // Actions by button ID
+}
+
+// AndroidPublicNotification is shown instead of the notification on a secure lock screen, with AndroidVisibilityPrivate.
+type AndroidPublicNotification struct {
+	Title   string `json:"title,omitempty"`
+	Alert   string `json:"alert,omitempty"`
+	Summary string `json:"summary,omitempty"`
+}
+
+func (v *fieldValidator) androidOverride(path string, android *AndroidOverrideWithTemplate) {
+	v.templateRef(path+".template", android.Template)
+	v.actions(path+".actions", android.Actions)
+	if android.Priority < AndroidPriorityMin || android.Priority > AndroidPriorityMax {
+		v.fail(path+".priority", fmt.Sprintf("must be between %d and %d", AndroidPriorityMin, AndroidPriorityMax))
+	}
+	if vis := android.Visibility; vis != nil && (*vis < AndroidVisibilitySecret || *vis > AndroidVisibilityPublic) {
+		v.fail(path+".visibility", fmt.Sprintf("must be between %d and %d", AndroidVisibilitySecret, AndroidVisibilityPublic))
+	}
+	switch android.DeliveryPriority {
+	case "", AndroidDeliveryPriorityHigh, AndroidDeliveryPriorityNormal:
+	default:
+		v.fail(path+".delivery_priority", fmt.Sprintf("unknown delivery priority %q", android.DeliveryPriority))
+	}
+	if style := android.Style; style != nil {
+		switch style.Type {
+		case AndroidStyleBigPicture:
+			v.required(path+".style.big_picture", style.BigPicture != "")
+		case AndroidStyleBigText:
+			v.required(path+".style.big_text", style.BigText != "")
+		case AndroidStyleInbox:
+			v.required(path+".style.lines", len(style.Lines) > 0)
+		default:
+			v.fail(path+".style.type", fmt.Sprintf("unknown style %q", style.Type))
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package airship
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAndroidOverride_MarshalJSON(t *testing.T) {
+	secret := AndroidVisibilitySecret
+	android := AndroidOverrideWithTemplate{
+		Category:            AndroidCategoryPromo,
+		NotificationChannel: "promotions",
+		Priority:            AndroidPriorityHigh,
+		Visibility:          &secret,
+		DeliveryPriority:    AndroidDeliveryPriorityHigh,
+		TimeToLive:          ExpireAfter(10 * time.Minute),
+		LocalOnly:           true,
+		Style:               &AndroidStyle{Type: AndroidStyleInbox, Lines: []string{"One", "Two"}, Summary: "+3 more"},
+		Wearable: &AndroidWearable{
+			BackgroundImage: "https://example.com/bg.png",
+			ExtraPages:      []AndroidWearablePage{{Title: "Details", Alert: "More text"}},
+		},
+		PublicNotification: &AndroidPublicNotification{Title: "New message"},
+	}
+	actual, err := json.Marshal(android)
+	require.Nil(t, err)
+	assert.JSONEq(t, `{
+		"category": "promo",
+		"notification_channel": "promotions",
+		"priority": 1,
+		"visibility": -1,
+		"delivery_priority": "high",
+		"time_to_live": 600,
+		"local_only": true,
+		"style": {"type": "inbox", "lines": ["One", "Two"], "summary": "+3 more"},
+		"wearable": {
+			"background_image": "https://example.com/bg.png",
+			"extra_pages": [{"title": "Details", "alert": "More text"}]
+		},
+		"public_notification": {"title": "New message"}
+	}`, string(actual))
+}
+
+func TestAndroidOverride_Validate(t *testing.T) {
+	payload := MakeSendPushPayload(templateIDA, []string{channelA}, nil, WithBigPicture(""))
+	payload.Notification.Android.Priority = 3
+	invalid := 2
+	payload.Notification.Android.Visibility = &invalid
+	payload.Notification.Android.DeliveryPriority = "urgent"
+	assert.EqualError(t, payload.Validate(), "airship: invalid payload: "+
+		"must be between -2 and 2 on notification.android.priority; "+
+		"must be between -1 and 1 on notification.android.visibility; "+
+		"unknown delivery priority \"urgent\" on notification.android.delivery_priority; "+
+		"missing required value on notification.android.style.big_picture")
+
+	payload = MakeSendPushPayload(templateIDA, []string{channelA}, nil)
+	payload.Notification.Android.Style = &AndroidStyle{Type: "carousel"}
+	assert.EqualError(t, payload.Validate(), "airship: invalid payload: unknown style \"carousel\" on notification.android.style.type")
+}
+
+// Private is 0, so it must still be sent when it is chosen explicitly.
+func TestAndroidOverride_PrivateVisibility(t *testing.T) {
+	payload := MakeSendPushPayload(templateIDA, []string{channelA}, nil,
+		WithAndroidVisibility(AndroidVisibilityPrivate, &AndroidPublicNotification{Title: "New message"}))
+	actual, err := json.Marshal(payload.Notification.Android)
+	require.Nil(t, err)
+	assert.JSONEq(t, `{
+		"template": {"template_id": "template-id-a"},
+		"visibility": 0,
+		"public_notification": {"title": "New message"}
+	}`, string(actual))
+
+	actual, err = json.Marshal(AndroidOverrideWithTemplate{})
+	require.Nil(t, err)
+	assert.JSONEq(t, `{}`, string(actual))
+}
@@ -0,0 +1,155 @@
+package airship
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"reflect"
+	"time"
+)
+
+// Limits Airship puts on a single attributes request.
+const (
+	MaxAttributeMutations    = 100  // Attribute mutations per request
+	MaxAttributeStringLength = 1024 // Characters in a text attribute value
+)
+
+// AttributeMutation sets or removes one custom attribute. Use MakeSetAttribute and MakeRemoveAttribute to
+// create them.
+// https://docs.airship.com/api/ua/#schemas-setorremoveattributes
+type AttributeMutation struct {
+	Action    string      // "set" or "remove"
+	Key       string      // Attribute key, or "key#instance_id" for JSON attributes
+	Value     interface{} // A string, number, time.Time, or a map or struct for JSON attributes
+	Timestamp time.Time   // When the change happened, optional
+}
+
+// MakeSetAttribute creates a mutation that sets the attribute key to value, which must be a string, a number,
+// a time.Time for date attributes, or a map, struct or json.RawMessage for JSON attributes.
+func MakeSetAttribute(key string, value interface{}) AttributeMutation {
+	return AttributeMutation{Action: "set", Key: key, Value: value}
+}
+
+// MakeRemoveAttribute creates a mutation that removes the attribute key.
+func MakeRemoveAttribute(key string) AttributeMutation {
+	return AttributeMutation{Action: "remove", Key: key}
+}
+
+// MarshalJSON converts Value to its JSON representation, dates as ISO-8601 in UTC.
+func (m AttributeMutation) MarshalJSON() ([]byte, error) {
+	body := struct {
+		Action    string      `json:"action"`
+		Key       string      `json:"key"`
+		Value     interface{} `json:"value,omitempty"`
+		Timestamp string      `json:"timestamp,omitempty"`
+	}{Action: m.Action, Key: m.Key, Value: m.Value}
+	switch t := m.Value.(type) {
+	case time.Time:
+		body.Value = formatAttributeTime(t)
+	case *time.Time:
+		if t != nil {
+			body.Value = formatAttributeTime(*t)
+		}
+	}
+	if !m.Timestamp.IsZero() {
+		body.Timestamp = formatAttributeTime(m.Timestamp)
+	}
+	return json.Marshal(body)
+}
+
+func formatAttributeTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05Z")
+}
+
+// checkAttributeValue returns why value can't be sent as an attribute value, or "" if it can.
+func checkAttributeValue(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "missing required value"
+	case time.Time, json.RawMessage:
+		return ""
+	case string:
+		if len([]rune(value)) > MaxAttributeStringLength {
+			return fmt.Sprintf("longer than %d characters", MaxAttributeStringLength)
+		}
+		return ""
+	}
+	v := reflect.Indirect(reflect.ValueOf(value))
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return ""
+	case reflect.Float32, reflect.Float64:
+		if math.IsNaN(v.Float()) || math.IsInf(v.Float(), 0) {
+			return "not a finite number"
+		}
+		return ""
+	case reflect.Map, reflect.Struct:
+		return ""
+	}
+	return fmt.Sprintf("unsupported type %T", value)
+}
+
+func (v *fieldValidator) attributes(path string, mutations []AttributeMutation) {
+	v.required(path, len(mutations) > 0)
+	if len(mutations) > MaxAttributeMutations {
+		v.fail(path, fmt.Sprintf("more than %d mutations", MaxAttributeMutations))
+	}
+	for i, m := range mutations {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		v.required(itemPath+".key", m.Key != "")
+		switch m.Action {
+		case "set":
+			if msg := checkAttributeValue(m.Value); msg != "" {
+				v.fail(itemPath+".value", msg)
+			}
+		case "remove":
+		default:
+			v.fail(itemPath+".action", fmt.Sprintf("unknown action %q", m.Action))
+		}
+	}
+}
+
+// attributesRequest is the body of the named user attributes request.
+type attributesRequest struct {
+	Attributes []AttributeMutation `json:"attributes"`
+}
+
+// Validate checks the mutations against the request limits.
+func (r attributesRequest) Validate() error {
+	v := fieldValidator{}
+	v.attributes("attributes", r.Attributes)
+	return v.err()
+}
+
+// channelAttributesRequest is the body of the channel attributes request.
+type channelAttributesRequest struct {
+	Audience   ChannelAudience     `json:"audience"`
+	Attributes []AttributeMutation `json:"attributes"`
+}
+
+// Validate checks that the request selects some channels and the mutations are within the request limits.
+func (r channelAttributesRequest) Validate() error {
+	v := fieldValidator{}
+	v.channelAudience("audience", &r.Audience)
+	v.attributes("attributes", r.Attributes)
+	return v.err()
+}
+
+// UpdateAttributes applies the attribute mutations to every channel in audience.
+// https://docs.airship.com/api/ua/#operation-api-channels-attributes-post
+func (s *ChannelService) UpdateAttributes(ctx context.Context, audience ChannelAudience, mutations ...AttributeMutation) error {
+	body := channelAttributesRequest{Audience: audience, Attributes: mutations}
+	return s.client.Do(ctx, http.MethodPost, EndpointChannels+"/attributes", &body, nil)
+}
+
+// UpdateAttributes applies the attribute mutations to a named user.
+// https://docs.airship.com/api/ua/#operation-api-named_users-named_user_id-attributes-post
+func (s *NamedUserService) UpdateAttributes(ctx context.Context, namedUserID string, mutations ...AttributeMutation) error {
+	body := attributesRequest{Attributes: mutations}
+	endpoint := EndpointNamedUsers + "/" + url.PathEscape(namedUserID) + "/attributes"
+	return s.client.Do(ctx, http.MethodPost, endpoint, &body, nil)
+}
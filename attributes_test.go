@@ -0,0 +1,105 @@
+package airship
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sean-rn/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttributeMutation_MarshalJSON(t *testing.T) {
+	est := time.FixedZone("EST", -5*60*60)
+	birthdate := time.Date(1990, 1, 2, 22, 30, 0, 600, est)
+	testCases := []struct {
+		name     string
+		input    AttributeMutation
+		expected string
+	}{
+		{name: "string", input: MakeSetAttribute("first_name", "Testy"), expected: `{"action": "set", "key": "first_name", "value": "Testy"}`},
+		{name: "int", input: MakeSetAttribute("age", 42), expected: `{"action": "set", "key": "age", "value": 42}`},
+		{name: "float", input: MakeSetAttribute("score", 9.5), expected: `{"action": "set", "key": "score", "value": 9.5}`},
+		{
+			name:     "date",
+			input:    MakeSetAttribute("birthdate", time.Date(1990, 1, 2, 22, 30, 0, 0, est)),
+			expected: `{"action": "set", "key": "birthdate", "value": "1990-01-03T03:30:00Z"}`,
+		},
+		{
+			name:     "date pointer",
+			input:    MakeSetAttribute("birthdate", &birthdate),
+			expected: `{"action": "set", "key": "birthdate", "value": "1990-01-03T03:30:00Z"}`,
+		},
+		{
+			name:     "json",
+			input:    MakeSetAttribute("car#1", map[string]interface{}{"make": "Ford"}),
+			expected: `{"action": "set", "key": "car#1", "value": {"make": "Ford"}}`,
+		},
+		{
+			name:     "remove with timestamp",
+			input:    AttributeMutation{Action: "remove", Key: "age", Timestamp: time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC)},
+			expected: `{"action": "remove", "key": "age", "timestamp": "2022-03-04T05:06:07Z"}`,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := json.Marshal(tt.input)
+			require.Nil(t, err)
+			assert.JSONEq(t, tt.expected, string(actual))
+		})
+	}
+}
+
+func TestChannelService_UpdateAttributes(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "POST", req.Method)
+		assert.Equal(t, "https://go.urbanairship.com/api/channels/attributes", req.URL.String())
+		assertBodyJSONEqual(t, `{
+			"audience": { "android_channel": ["channel-b"] },
+			"attributes": [
+				{ "action": "set", "key": "age", "value": 42 },
+				{ "action": "remove", "key": "first_name" }
+			]
+		}`, req.Body)
+		rw.Write([]byte(`{"ok": true}`))
+	})
+	service := NewChannelService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	err := service.UpdateAttributes(context.Background(), ChannelAudience{AndroidChannels: []string{channelB}},
+		MakeSetAttribute("age", 42), MakeRemoveAttribute("first_name"))
+	assert.Nil(t, err)
+}
+
+func TestUpdateAttributes_Validates(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		t.Error("no request should have been sent")
+	})
+	testConnection := New(WithHTTPClient(client), WithBearerAuth(TestBearerToken))
+	ctx := context.Background()
+
+	err := NewChannelService(testConnection).UpdateAttributes(ctx, ChannelAudience{})
+	assert.EqualError(t, err, "airship: invalid payload: missing required value on audience; missing required value on attributes")
+
+	err = NewNamedUserService(testConnection).UpdateAttributes(ctx, "user-1",
+		MakeSetAttribute("", "x"),
+		MakeSetAttribute("long", strings.Repeat("x", MaxAttributeStringLength+1)),
+		MakeSetAttribute("nan", math.NaN()),
+		MakeSetAttribute("flag", true),
+		AttributeMutation{Action: "unset", Key: "age"},
+	)
+	assert.EqualError(t, err, "airship: invalid payload: missing required value on attributes[0].key; "+
+		"longer than 1024 characters on attributes[1].value; not a finite number on attributes[2].value; "+
+		"unsupported type bool on attributes[3].value; unknown action \"unset\" on attributes[4].action")
+
+	mutations := make([]AttributeMutation, MaxAttributeMutations+1)
+	for i := range mutations {
+		mutations[i] = MakeRemoveAttribute("key")
+	}
+	err = NewNamedUserService(testConnection).UpdateAttributes(ctx, "user-1", mutations...)
+	assert.EqualError(t, err, "airship: invalid payload: more than 100 mutations on attributes")
+}
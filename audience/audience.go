@@ -0,0 +1,219 @@
+// Package audience builds Airship audience selectors, including compound selectors combining them with boolean logic.
+// https://docs.airship.com/api/ua/#schemas-audienceselector
+// For example:
+//    payload.Audience = audience.And(audience.Tag("vip", "crm"), audience.Not(audience.Segment(segmentID)))
+package audience
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Selector is an audience selector, usable as the Audience of airship.PushObject or airship.PushTemplatePayload.
+type Selector struct {
+	value interface{}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s Selector) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, so selectors returned by Airship, such as the audience of a schedule's
+// push, can be decoded and sent again.
+func (s *Selector) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &s.value)
+}
+
+// ValidateAudience reports an error if the selector, or any selector it combines, is empty or selects by an empty
+// ID. Selectors decoded from JSON are checked the same way.
+func (s Selector) ValidateAudience() error {
+	switch value := s.value.(type) {
+	case string:
+		if value != "all" {
+			return fmt.Errorf("unknown selector %q", value)
+		}
+		return nil
+	case map[string]interface{}:
+		if len(value) == 0 {
+			return fmt.Errorf("empty selector")
+		}
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if err := validateValue(key, value[key]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("empty selector")
+}
+
+// validateValue checks the value of one key of a selector object, as built by this package or decoded from JSON.
+func validateValue(key string, value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return fmt.Errorf("empty %s selector", key)
+		}
+	case []string:
+		if len(v) == 0 {
+			return fmt.Errorf("empty %s selector", key)
+		}
+		for _, id := range v {
+			if id == "" {
+				return fmt.Errorf("empty %s selector", key)
+			}
+		}
+	case map[string]string:
+		for _, field := range []string{"sender", "msisdn"} {
+			if v[field] == "" {
+				return fmt.Errorf("empty %s.%s selector", key, field)
+			}
+		}
+	case []Selector:
+		if len(v) == 0 {
+			return fmt.Errorf("empty %s selector", key)
+		}
+		for _, selector := range v {
+			if err := selector.ValidateAudience(); err != nil {
+				return err
+			}
+		}
+	case Selector:
+		return v.ValidateAudience()
+	case []interface{}:
+		// An array of IDs or of selectors, decoded from JSON.
+		if len(v) == 0 {
+			return fmt.Errorf("empty %s selector", key)
+		}
+		for _, element := range v {
+			if err := validateValue(key, element); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		// A nested selector, or the phone number of an sms_id selector, decoded from JSON.
+		if key != "sms_id" {
+			return Selector{value: v}.ValidateAudience()
+		}
+		for _, field := range []string{"sender", "msisdn"} {
+			if id, _ := v[field].(string); id == "" {
+				return fmt.Errorf("empty %s.%s selector", key, field)
+			}
+		}
+	case nil:
+		return fmt.Errorf("empty %s selector", key)
+	}
+	return nil
+}
+
+// atomic builds a selector object with a single key.
+func atomic(key string, value interface{}) Selector {
+	return Selector{value: map[string]interface{}{key: value}}
+}
+
+// ids builds the value of a selector that accepts either one ID or an array of IDs, any of which may match.
+func ids(values []string) interface{} {
+	if len(values) == 1 {
+		return values[0]
+	}
+	return values
+}
+
+//
+// Atomic Selectors https://docs.airship.com/api/ua/#schemas-atomicselector
+//
+
+// All selects every device of the device types the push is sent to.
+func All() Selector {
+	return Selector{value: "all"}
+}
+
+// Tag selects devices with the tag. If group is empty, the "device" tag group is used.
+func Tag(tag, group string) Selector {
+	value := map[string]interface{}{"tag": tag}
+	if group != "" {
+		value["group"] = group
+	}
+	return Selector{value: value}
+}
+
+// Segment selects the members of a segment by its ID.
+func Segment(id string) Selector {
+	return atomic("segment", id)
+}
+
+// StaticList selects the members of a static list by its name.
+func StaticList(name string) Selector {
+	return atomic("static_list", name)
+}
+
+// SubscriptionList selects the members of a subscription list by its ID.
+func SubscriptionList(id string) Selector {
+	return atomic("subscription_list", id)
+}
+
+// Channel selects channels of any platform by channel ID.
+func Channel(channelIDs ...string) Selector {
+	return atomic("channel", ids(channelIDs))
+}
+
+// IOSChannel selects iOS channels by channel ID.
+func IOSChannel(channelIDs ...string) Selector {
+	return atomic("ios_channel", ids(channelIDs))
+}
+
+// AndroidChannel selects Android channels by channel ID.
+func AndroidChannel(channelIDs ...string) Selector {
+	return atomic("android_channel", ids(channelIDs))
+}
+
+// AmazonChannel selects Amazon channels by channel ID.
+func AmazonChannel(channelIDs ...string) Selector {
+	return atomic("amazon_channel", ids(channelIDs))
+}
+
+// OpenChannel selects open channels by channel ID.
+func OpenChannel(channelIDs ...string) Selector {
+	return atomic("open_channel", ids(channelIDs))
+}
+
+// NamedUser selects every channel associated with the named users.
+func NamedUser(namedUserIDs ...string) Selector {
+	return atomic("named_user", ids(namedUserIDs))
+}
+
+// EmailAddress selects email channels by address.
+func EmailAddress(addresses ...string) Selector {
+	return atomic("email_address", ids(addresses))
+}
+
+// SMSID selects the SMS channel for a phone number registered with a sender.
+func SMSID(sender, msisdn string) Selector {
+	return atomic("sms_id", map[string]string{"sender": sender, "msisdn": msisdn})
+}
+
+//
+// Compound Selectors https://docs.airship.com/api/ua/#schemas-compoundselector
+//
+
+// And selects devices matched by every one of selectors.
+func And(selectors ...Selector) Selector {
+	return atomic("and", selectors)
+}
+
+// Or selects devices matched by any of selectors.
+func Or(selectors ...Selector) Selector {
+	return atomic("or", selectors)
+}
+
+// Not selects devices that are not matched by selector.
+func Not(selector Selector) Selector {
+	return atomic("not", selector)
+}
@@ -0,0 +1,96 @@
+package audience
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelector_MarshalJSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    Selector
+		expected string
+	}{
+		{name: "all", input: All(), expected: `"all"`},
+		{name: "tag", input: Tag("vip", "crm"), expected: `{"tag": "vip", "group": "crm"}`},
+		{name: "tag in default group", input: Tag("vip", ""), expected: `{"tag": "vip"}`},
+		{name: "segment", input: Segment("seg-1"), expected: `{"segment": "seg-1"}`},
+		{name: "static list", input: StaticList("subscribers"), expected: `{"static_list": "subscribers"}`},
+		{name: "subscription list", input: SubscriptionList("news"), expected: `{"subscription_list": "news"}`},
+		{name: "one channel", input: Channel("channel-a"), expected: `{"channel": "channel-a"}`},
+		{name: "many channels", input: Channel("channel-a", "channel-b"), expected: `{"channel": ["channel-a", "channel-b"]}`},
+		{name: "ios channel", input: IOSChannel("channel-a"), expected: `{"ios_channel": "channel-a"}`},
+		{name: "android channel", input: AndroidChannel("channel-a"), expected: `{"android_channel": "channel-a"}`},
+		{name: "amazon channel", input: AmazonChannel("channel-a"), expected: `{"amazon_channel": "channel-a"}`},
+		{name: "open channel", input: OpenChannel("channel-a"), expected: `{"open_channel": "channel-a"}`},
+		{name: "named user", input: NamedUser("user-1"), expected: `{"named_user": "user-1"}`},
+		{name: "email address", input: EmailAddress("a@example.com"), expected: `{"email_address": "a@example.com"}`},
+		{name: "sms id", input: SMSID("12062071886", "19785551212"), expected: `{"sms_id": {"sender": "12062071886", "msisdn": "19785551212"}}`},
+		{
+			name:  "compound",
+			input: And(Tag("vip", "crm"), Not(Segment("seg-1")), Or(IOSChannel("channel-a"), AndroidChannel("channel-b"))),
+			expected: `{
+				"and": [
+					{"tag": "vip", "group": "crm"},
+					{"not": {"segment": "seg-1"}},
+					{"or": [
+						{"ios_channel": "channel-a"},
+						{"android_channel": "channel-b"}
+					]}
+				]
+			}`,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			bytes, err := json.Marshal(tt.input)
+			require.Nil(t, err)
+			assert.JSONEq(t, tt.expected, string(bytes))
+		})
+	}
+}
+
+func TestSelector_ValidateAudience(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    Selector
+		expected string
+	}{
+		{name: "all", input: All()},
+		{name: "compound", input: And(Tag("vip", ""), Not(Segment("seg-1")), Or(Channel("a", "b"), SMSID("1", "2")))},
+		{name: "zero value", input: Selector{}, expected: "empty selector"},
+		{name: "empty object", input: decode(t, `{}`), expected: "empty selector"},
+		{name: "decoded", input: decode(t, `{"and": [{"tag": "vip"}, {"not": {"segment": "seg-1"}}, {"channel": ["a", "b"]}, {"sms_id": {"sender": "1", "msisdn": "2"}}]}`)},
+		{name: "decoded no channels", input: decode(t, `{"channel": []}`), expected: "empty channel selector"},
+		{name: "decoded empty channel", input: decode(t, `{"channel": ["a", ""]}`), expected: "empty channel selector"},
+		{name: "decoded empty nested", input: decode(t, `{"or": [{"segment": "seg-1"}, {"not": {}}]}`), expected: "empty selector"},
+		{name: "decoded empty msisdn", input: decode(t, `{"sms_id": {"sender": "1"}}`), expected: "empty sms_id.msisdn selector"},
+		{name: "unknown string", input: decode(t, `"everyone"`), expected: `unknown selector "everyone"`},
+		{name: "no channels", input: Channel(), expected: "empty channel selector"},
+		{name: "empty channel", input: Channel(""), expected: "empty channel selector"},
+		{name: "empty among channels", input: Channel("a", ""), expected: "empty channel selector"},
+		{name: "empty tag", input: Tag("", "crm"), expected: "empty tag selector"},
+		{name: "empty msisdn", input: SMSID("12062071886", ""), expected: "empty sms_id.msisdn selector"},
+		{name: "empty and", input: And(), expected: "empty and selector"},
+		{name: "nested", input: Or(Segment("seg-1"), Not(NamedUser())), expected: "empty named_user selector"},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.ValidateAudience()
+			if tt.expected == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expected)
+			}
+		})
+	}
+}
+
+func decode(t *testing.T, data string) Selector {
+	var selector Selector
+	require.Nil(t, json.Unmarshal([]byte(data), &selector))
+	return selector
+}
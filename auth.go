@@ -0,0 +1,56 @@
+package airship
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+)
+
+// Authenticator adds credentials to each request sent by the Airship Client.
+// Apply is called for every attempt, so implementations may look up credentials each time in order to rotate them.
+type Authenticator interface {
+	Apply(ctx context.Context, req *http.Request) error
+}
+
+// AuthenticatorFunc is an adapter to allow the use of ordinary functions as Authenticators.
+type AuthenticatorFunc func(ctx context.Context, req *http.Request) error
+
+// Apply calls f(ctx, req).
+func (f AuthenticatorFunc) Apply(ctx context.Context, req *http.Request) error {
+	return f(ctx, req)
+}
+
+// refreshableAuthenticator is implemented by Authenticators whose credentials may be rejected before they
+// expire. After a 401 response the credentials used for req are discarded and the request is tried once more.
+type refreshableAuthenticator interface {
+	Authenticator
+	invalidate(req *http.Request)
+}
+
+// WithAuthenticator configures the Airship Client to authenticate requests using auth.
+func WithAuthenticator(auth Authenticator) ClientOption {
+	return func(c *uaHTTPClient) {
+		c.auth = auth
+	}
+}
+
+// BasicAuthenticator returns an Authenticator that uses HTTP Basic Auth with fixed credentials.
+// https://docs.airship.com/api/ua/#security-basicauth
+func BasicAuthenticator(appKey, masterSecret string) Authenticator {
+	return headerAuthenticator("Basic " + base64.StdEncoding.EncodeToString([]byte(appKey+":"+masterSecret)))
+}
+
+// BearerAuthenticator returns an Authenticator that uses a fixed Bearer token.
+// https://docs.airship.com/api/ua/#security-bearerauth
+func BearerAuthenticator(token string) Authenticator {
+	return headerAuthenticator("Bearer " + token)
+}
+
+// headerAuthenticator sets a precomputed Authorization header.
+type headerAuthenticator string
+
+// Apply implements Authenticator.
+func (h headerAuthenticator) Apply(ctx context.Context, req *http.Request) error {
+	req.Header.Set("Authorization", string(h))
+	return nil
+}
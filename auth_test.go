@@ -0,0 +1,70 @@
+package airship
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/sean-rn/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Credentials looked up by an Authenticator on each request can change while the client is running.
+func TestWithAuthenticator_RotatesCredentials(t *testing.T) {
+	var received []string
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		received = append(received, req.Header.Get("Authorization"))
+	})
+
+	secret := "master-secret"
+	auth := AuthenticatorFunc(func(ctx context.Context, req *http.Request) error {
+		req.SetBasicAuth("app-key", secret) // e.g. read from a secret store
+		return nil
+	})
+	testConnection := New(WithHTTPClient(client), WithAuthenticator(auth))
+
+	require.Nil(t, testConnection.InvokeEndpoint(http.MethodPost, "/api/push", map[string]string{}))
+	secret = "rotated-secret"
+	require.Nil(t, testConnection.InvokeEndpoint(http.MethodPost, "/api/push", map[string]string{}))
+
+	assert.Equal(t, []string{
+		"Basic YXBwLWtleTptYXN0ZXItc2VjcmV0",
+		"Basic YXBwLWtleTpyb3RhdGVkLXNlY3JldA==",
+	}, received)
+}
+
+// Errors from the Authenticator are returned without sending the request.
+func TestWithAuthenticator_Error(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		t.Error("no request should have been sent")
+	})
+
+	auth := AuthenticatorFunc(func(ctx context.Context, req *http.Request) error {
+		return fmt.Errorf("secret store unavailable")
+	})
+	testConnection := New(WithHTTPClient(client), WithAuthenticator(auth))
+
+	err := testConnection.InvokeEndpoint(http.MethodPost, "/api/push", map[string]string{})
+	assert.EqualError(t, err, "secret store unavailable")
+}
+
+func TestBuiltInAuthenticators(t *testing.T) {
+	testCases := []struct {
+		name     string
+		auth     Authenticator
+		expected string
+	}{
+		{name: "basic", auth: BasicAuthenticator("app-key", "master-secret"), expected: "Basic YXBwLWtleTptYXN0ZXItc2VjcmV0"},
+		{name: "bearer", auth: BearerAuthenticator(TestBearerToken), expected: "Bearer test-ua-token"},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, BaseURL, nil)
+			require.Nil(t, err)
+			require.Nil(t, tt.auth.Apply(context.Background(), req))
+			assert.Equal(t, tt.expected, req.Header.Get("Authorization"))
+		})
+	}
+}
@@ -1,10 +1,13 @@
 package airship
 
-// MakePushTemplatePayload creates a new push template payload
-func MakePushTemplatePayload(templateID string, channels []string, substitutions map[string]string) PushTemplatePayload {
+// MakePushTemplatePayload creates a new push template payload, sent to iOS and Android unless deviceTypes are given.
+func MakePushTemplatePayload(templateID string, channels []string, substitutions map[string]string, deviceTypes ...DeviceType) PushTemplatePayload {
+	if len(deviceTypes) == 0 {
+		deviceTypes = []DeviceType{DeviceTypeIOS, DeviceTypeAndroid}
+	}
 	return PushTemplatePayload{
 		Audience:    AudienceSelector{Channels: channels},
-		DeviceTypes: []string{"ios", "android"},
+		DeviceTypes: deviceTypes,
 		MergeData: MergeData{
 			Substitutions: substitutions,
 			TemplateID:    templateID,
@@ -12,27 +15,41 @@ func MakePushTemplatePayload(templateID string, channels []string, substitutions
 	}
 }
 
-// MakeSendPushPayload creates a new push template payload
+// MakeSendPushPayload creates a new push template payload, sent to iOS and Android. The device types are those
+// the notification has overrides for once the options are applied.
 func MakeSendPushPayload(templateID string, channels []string, substitutions map[string]string, options ...PushNotificationOption) PushObject {
-	notification := NotificationObject{
-		Android: &AndroidOverrideWithTemplate{
-			Template: &TemplateRef{TemplateID: templateID},
-		},
-		IOS: &IOSOverrideWithTemplate{
-			Template: &TemplateRef{TemplateID: templateID},
-		},
-	}
-	for _, fn := range options {
-		fn(&notification)
+	notification := newTemplateNotification(templateID, DeviceTypeIOS, DeviceTypeAndroid)
+	applyOptions(&notification, options)
+	return PushObject{
+		Audience:         AudienceSelector{Channels: channels},
+		DeviceTypes:      notification.deviceTypes(),
+		GlobalAttributes: substitutions,
+		Notification:     notification,
 	}
+}
+
+// MakeSendPushPayloadFor is like MakeSendPushPayload, but sends to exactly deviceTypes. Overrides using the
+// template are created for them before the options are applied, and the overrides of other device types are
+// removed afterwards. Email and WNS notifications can't be made from the template alone, so those device types
+// also need WithEmailOverride or WithWNSOverride, or the payload fails validation.
+func MakeSendPushPayloadFor(templateID string, channels []string, substitutions map[string]string, deviceTypes []DeviceType, options ...PushNotificationOption) PushObject {
+	notification := newTemplateNotification(templateID, deviceTypes...)
+	applyOptions(&notification, options)
+	notification.removeOverridesExcept(deviceTypes)
 	return PushObject{
 		Audience:         AudienceSelector{Channels: channels},
-		DeviceTypes:      []string{"ios", "android"},
+		DeviceTypes:      deviceTypes,
 		GlobalAttributes: substitutions,
 		Notification:     notification,
 	}
 }
 
+func applyOptions(notif *NotificationObject, options []PushNotificationOption) {
+	for _, fn := range options {
+		fn(notif)
+	}
+}
+
 //
 // Mutator Config -- Experimental
 //
@@ -67,7 +84,7 @@ func WithOpenURLAction(url string) PushNotificationOption {
 	}
 }
 
-// WithExtra adds "extra" data to IOS & Android notification overrides.
+// WithExtra adds "extra" data to the IOS, Android, Amazon, web and open channel notification overrides.
 func WithExtra(extra map[string]string) PushNotificationOption {
 	return func(notif *NotificationObject) {
 		if notif.Android != nil {
@@ -76,6 +93,15 @@ func WithExtra(extra map[string]string) PushNotificationOption {
 		if notif.IOS != nil {
 			notif.IOS.Extra = extra
 		}
+		if notif.Amazon != nil {
+			notif.Amazon.Extra = extra
+		}
+		if notif.Web != nil {
+			notif.Web.Extra = extra
+		}
+		for _, open := range notif.Open {
+			open.Extra = extra
+		}
 	}
 }
 
@@ -87,3 +113,72 @@ func WithShortenLinks(value bool) PushNotificationOption {
 		}
 	}
 }
+
+// WithAndroidChannel sets the Android notification channel the notification is posted to.
+func WithAndroidChannel(channelID string) PushNotificationOption {
+	return func(notif *NotificationObject) {
+		if notif.Android != nil {
+			notif.Android.NotificationChannel = channelID
+		}
+	}
+}
+
+// WithAndroidVisibility sets what the Android notification shows on a secure lock screen, one of the
+// AndroidVisibility* constants. public is shown instead with AndroidVisibilityPrivate, and may be nil.
+func WithAndroidVisibility(visibility int, public *AndroidPublicNotification) PushNotificationOption {
+	return func(notif *NotificationObject) {
+		if notif.Android != nil {
+			notif.Android.Visibility = &visibility
+			notif.Android.PublicNotification = public
+		}
+	}
+}
+
+// WithBigPicture shows the image at imageURL when the Android notification is expanded.
+func WithBigPicture(imageURL string) PushNotificationOption {
+	return func(notif *NotificationObject) {
+		if notif.Android != nil {
+			notif.Android.Style = &AndroidStyle{Type: AndroidStyleBigPicture, BigPicture: imageURL}
+		}
+	}
+}
+
+// WithBigText shows text when the Android notification is expanded.
+func WithBigText(text string) PushNotificationOption {
+	return func(notif *NotificationObject) {
+		if notif.Android != nil {
+			notif.Android.Style = &AndroidStyle{Type: AndroidStyleBigText, BigText: text}
+		}
+	}
+}
+
+// WithOpenOverride sends the notification to an open channel platform, with override.
+func WithOpenOverride(platform string, override OpenOverride) PushNotificationOption {
+	return func(notif *NotificationObject) {
+		if notif.Open == nil {
+			notif.Open = map[string]*OpenOverride{}
+		}
+		notif.Open[platform] = &override
+	}
+}
+
+// WithWebOverride sends the notification to web browsers, with override.
+func WithWebOverride(override WebOverrideWithTemplate) PushNotificationOption {
+	return func(notif *NotificationObject) {
+		notif.Web = &override
+	}
+}
+
+// WithWNSOverride sends the notification to Windows devices, with override.
+func WithWNSOverride(override WNSOverride) PushNotificationOption {
+	return func(notif *NotificationObject) {
+		notif.WNS = &override
+	}
+}
+
+// WithEmailOverride sends the notification to email channels, with override.
+func WithEmailOverride(override EmailOverrideWithTemplate) PushNotificationOption {
+	return func(notif *NotificationObject) {
+		notif.Email = &override
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/sean-rn/go-airship/audience"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -40,6 +41,21 @@ func TestNewPushTemplatePayload(t *testing.T) {
 	assert.JSONEq(t, expected, string(json))
 }
 
+// A push template payload can be decoded, for example from a file, and sent again unchanged.
+func TestPushTemplatePayload_UnmarshalJSON(t *testing.T) {
+	payload := MakePushTemplatePayload(templateIDA, []string{channelA}, map[string]string{"ActivityID": "2342"})
+	data, err := json.Marshal(&payload)
+	require.Nil(t, err)
+
+	var decoded PushTemplatePayload
+	require.Nil(t, json.Unmarshal(data, &decoded))
+	assert.IsType(t, audience.Selector{}, decoded.Audience)
+	assert.Nil(t, decoded.Validate())
+	again, err := json.Marshal(&decoded)
+	require.Nil(t, err)
+	assert.JSONEq(t, string(data), string(again))
+}
+
 func TestNewSendPushPayload(t *testing.T) {
 	const expected = `{
 		"audience": {
@@ -164,3 +180,63 @@ func TestNewSendPushPayload_WithDeepLink(t *testing.T) {
 	require.Nil(t, err)
 	assert.JSONEq(t, expected, string(json))
 }
+
+func TestNewSendPushPayload_WithAudienceSelector(t *testing.T) {
+	const expected = `{
+		"audience": {
+			"and": [
+				{"tag": "vip", "group": "crm"},
+				{"not": {"segment": "seg-1"}}
+			]
+		},
+		"notification": {
+			"ios": {
+				"template": {
+					"template_id": "template-id-a"
+				}
+			},
+			"android": {
+				"template": {
+					"template_id": "template-id-a"
+				}
+			}
+		},
+		"device_types": ["ios", "android"]
+	}`
+	payload := MakeSendPushPayload(templateIDA, nil, nil)
+	payload.Audience = audience.And(audience.Tag("vip", "crm"), audience.Not(audience.Segment("seg-1")))
+	json, err := json.Marshal(&payload)
+	require.Nil(t, err)
+	assert.JSONEq(t, expected, string(json))
+}
+
+func TestNewSendPushPayload_WithAndroidOptions(t *testing.T) {
+	const expected = `{
+		"audience": {
+			"channel": ["channel-a"]
+		},
+		"notification": {
+			"ios": {
+				"template": {
+					"template_id": "template-id-a"
+				}
+			},
+			"android": {
+				"template": {
+					"template_id": "template-id-a"
+				},
+				"notification_channel": "promotions",
+				"style": {
+					"type": "big_picture",
+					"big_picture": "https://example.com/sale.png"
+				}
+			}
+		},
+		"device_types": ["ios", "android"]
+	}`
+	payload := MakeSendPushPayload(templateIDA, []string{channelA}, nil,
+		WithAndroidChannel("promotions"), WithBigPicture("https://example.com/sale.png"))
+	json, err := json.Marshal(&payload)
+	require.Nil(t, err)
+	assert.JSONEq(t, expected, string(json))
+}
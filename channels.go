@@ -0,0 +1,76 @@
+package airship
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Channel is a device, browser, email address or phone number that can receive messages.
+// https://docs.airship.com/api/ua/#schemas-channelobject
+type Channel struct {
+	ChannelID        string              `json:"channel_id"`
+	DeviceType       string              `json:"device_type"` // "ios", "android", "amazon", "web", "email", "sms", "open"
+	Installed        bool                `json:"installed"`
+	OptIn            bool                `json:"opt_in"`
+	Background       bool                `json:"background,omitempty"`
+	PushAddress      string              `json:"push_address,omitempty"` // Platform specific push token
+	NamedUserID      string              `json:"named_user_id,omitempty"`
+	Alias            string              `json:"alias,omitempty"`
+	Tags             []string            `json:"tags,omitempty"`       // Tags in the "device" tag group
+	TagGroups        map[string][]string `json:"tag_groups,omitempty"` // Tags by tag group
+	Created          Timestamp           `json:"created"`
+	LastRegistration Timestamp           `json:"last_registration"`
+}
+
+// ChannelAudience selects channels by platform in the tags and attributes requests.
+// https://docs.airship.com/api/ua/#operation-api-channels-tags-post
+type ChannelAudience struct {
+	IOSChannels     []string `json:"ios_channel,omitempty"`
+	AndroidChannels []string `json:"android_channel,omitempty"`
+	AmazonChannels  []string `json:"amazon_channel,omitempty"`
+	WebChannels     []string `json:"web_channel,omitempty"`
+	Channels        []string `json:"channel,omitempty"` // Channels of other platforms, such as email and SMS
+}
+
+func (v *fieldValidator) channelAudience(path string, a *ChannelAudience) {
+	v.required(path, len(a.IOSChannels)+len(a.AndroidChannels)+len(a.AmazonChannels)+len(a.WebChannels)+len(a.Channels) > 0)
+}
+
+// channelResponse is the body returned by the channel lookup.
+type channelResponse struct {
+	OK      bool    `json:"ok"`
+	Channel Channel `json:"channel"`
+}
+
+// channelRegistrationResponse is the body returned when an email address or phone number is registered.
+type channelRegistrationResponse struct {
+	OK        bool   `json:"ok"`
+	ChannelID string `json:"channel_id"`
+}
+
+// ChannelService invokes the Airship channels endpoints through a Client.
+type ChannelService struct {
+	client Client
+}
+
+// NewChannelService creates a ChannelService that sends its requests using client.
+func NewChannelService(client Client) *ChannelService {
+	return &ChannelService{client: client}
+}
+
+// Get looks up a channel by ID.
+// https://docs.airship.com/api/ua/#operation-api-channels-channel_id-get
+func (s *ChannelService) Get(ctx context.Context, channelID string) (*Channel, error) {
+	var resp channelResponse
+	if err := s.client.Do(ctx, http.MethodGet, EndpointChannels+"/"+url.PathEscape(channelID), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Channel, nil
+}
+
+// List returns a Pager over every channel in the project.
+// https://docs.airship.com/api/ua/#operation-api-channels-get
+func (s *ChannelService) List() *Pager[Channel] {
+	return newPager[Channel](s.client, EndpointChannels, "channels")
+}
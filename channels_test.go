@@ -0,0 +1,80 @@
+package airship
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sean-rn/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelService_Get(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "GET", req.Method)
+		assert.Equal(t, "https://go.urbanairship.com/api/channels/channel-a", req.URL.String())
+		rw.Write([]byte(`{
+			"ok": true,
+			"channel": {
+				"channel_id": "channel-a",
+				"device_type": "ios",
+				"installed": true,
+				"opt_in": true,
+				"background": true,
+				"push_address": "FE66489F304DC75B8D6E8200DFF8A456E8DAEACEC428B427E9518741C92C6660",
+				"named_user_id": "user-1",
+				"created": "2013-08-08T20:41:06",
+				"last_registration": "2014-05-01T18:00:27",
+				"tags": ["tag1"],
+				"tag_groups": { "crm": ["vip"] }
+			}
+		}`))
+	})
+	service := NewChannelService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	channel, err := service.Get(context.Background(), channelA)
+	require.Nil(t, err)
+	assert.Equal(t, &Channel{
+		ChannelID:        channelA,
+		DeviceType:       "ios",
+		Installed:        true,
+		OptIn:            true,
+		Background:       true,
+		PushAddress:      "FE66489F304DC75B8D6E8200DFF8A456E8DAEACEC428B427E9518741C92C6660",
+		NamedUserID:      "user-1",
+		Tags:             []string{"tag1"},
+		TagGroups:        map[string][]string{"crm": {"vip"}},
+		Created:          Timestamp{time.Date(2013, 8, 8, 20, 41, 6, 0, time.UTC)},
+		LastRegistration: Timestamp{time.Date(2014, 5, 1, 18, 0, 27, 0, time.UTC)},
+	}, channel)
+}
+
+func TestChannelService_List(t *testing.T) {
+	pages := map[string]string{
+		"/api/channels": `{"ok": true, "next_page": "https://go.urbanairship.com/api/channels?start=channel-b", "channels": [
+			{ "channel_id": "channel-a", "device_type": "ios" }
+		]}`,
+		"/api/channels?start=channel-b": `{"ok": true, "next_page": "https://go.urbanairship.com/api/channels?start=channel-d", "channels": [
+			{ "channel_id": "channel-b", "device_type": "android" },
+			{ "channel_id": "channel-c", "device_type": "web" }
+		]}`,
+		"/api/channels?start=channel-d": `{"ok": true, "channels": []}`,
+	}
+	var requested []string
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		requested = append(requested, req.URL.RequestURI())
+		rw.Write([]byte(pages[req.URL.RequestURI()]))
+	})
+	service := NewChannelService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	var ids []string
+	pager := service.List()
+	for pager.Next(context.Background()) {
+		ids = append(ids, pager.Item().ChannelID)
+	}
+	require.Nil(t, pager.Err())
+	assert.Equal(t, []string{"channel-a", "channel-b", "channel-c"}, ids)
+	assert.Equal(t, []string{"/api/channels", "/api/channels?start=channel-b", "/api/channels?start=channel-d"}, requested)
+}
@@ -2,16 +2,19 @@ package airship
 
 import (
 	"bytes"
-	"encoding/base64"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 )
 
 const (
 	// BaseURL is the base of the API endpoints https://docs.airship.com/api/ua/#servers
 	BaseURL = "https://go.urbanairship.com"
+	// BaseURLEU is the base of the API endpoints for projects hosted in Airship's EU cloud.
+	BaseURLEU = "https://go.airship.eu"
 	// AcceptHeader is the value to send in the Accept header as required by docs.
 	AcceptHeader = "application/vnd.urbanairship+json; version=3;"
 )
@@ -26,6 +29,36 @@ const (
 	// EndpointCreateAndSend is the path of the "Create and Send" POST endpoint.
 	// https://docs.airship.com/api/ua/#operation-api-create-and-send-post
 	EndpointCreateAndSend = "/api/create-and-send"
+	// EndpointValidatePush is the path of the "Validate" POST endpoint, which checks a push object without sending it.
+	// https://docs.airship.com/api/ua/#operation-api-push-validate-post
+	EndpointValidatePush = "/api/push/validate"
+	// EndpointValidatePushToTemplate is the path of the "Validate Push to Template" POST endpoint.
+	// https://docs.airship.com/api/ua/#operation-api-templates-push-validate-post
+	EndpointValidatePushToTemplate = "/api/templates/push/validate"
+	// EndpointValidateCreateAndSend is the path of the "Validate Create and Send" POST endpoint.
+	// https://docs.airship.com/api/ua/#operation-api-create-and-send-validate-post
+	EndpointValidateCreateAndSend = "/api/create-and-send/validate"
+	// EndpointSchedules is the path of the "Schedules" endpoints, followed by "/{schedule_id}" for a single schedule.
+	// https://docs.airship.com/api/ua/#tag-schedules
+	EndpointSchedules = "/api/schedules"
+	// EndpointChannels is the path of the "Channels" endpoints, followed by "/{channel_id}" for a single channel.
+	// https://docs.airship.com/api/ua/#tag-channels
+	EndpointChannels = "/api/channels"
+	// EndpointEmailChannels is the path of the "Email" channel endpoints.
+	// https://docs.airship.com/api/ua/#tag-email
+	EndpointEmailChannels = "/api/channels/email"
+	// EndpointSMSChannels is the path of the "SMS" channel endpoints.
+	// https://docs.airship.com/api/ua/#tag-sms
+	EndpointSMSChannels = "/api/channels/sms"
+	// EndpointSMS is the path of the SMS sender endpoints, followed by "/{sender}/keywords" for keyword interactions.
+	// https://docs.airship.com/api/ua/#operation-api-sms-sender-keywords-post
+	EndpointSMS = "/api/sms"
+	// EndpointNamedUsers is the path of the "Named Users" endpoints.
+	// https://docs.airship.com/api/ua/#tag-named-users
+	EndpointNamedUsers = "/api/named_users"
+	// EndpointTemplates is the path of the "Templates" endpoints, followed by "/{template_id}" for a single template.
+	// https://docs.airship.com/api/ua/#tag-templates
+	EndpointTemplates = "/api/templates"
 )
 
 //go:generate mockery --name Client
@@ -34,13 +67,19 @@ const (
 // Client is the API for interacting with Urban Airship
 type Client interface {
 	InvokeEndpoint(method string, endpoint string, body interface{}) error
+	Do(ctx context.Context, method string, endpoint string, body interface{}, out interface{}) error
 }
 
 // Urban Airship HTTP API Client implementation
 type uaHTTPClient struct {
 	httpClient  *http.Client
-	authHeader  string
+	auth        Authenticator
 	endpointURL string
+	retry       *RetryPolicy
+	limiter     *rateLimiter
+	dryRun      bool
+
+	oauthTokenURL string // Set by WithOAuthTokenURL
 }
 
 // ClientOption are configuration functions that can be passed to New to configure the client.
@@ -60,24 +99,68 @@ func New(options ...ClientOption) Client {
 	if client.endpointURL == "" {
 		client.endpointURL = BaseURL
 	}
+	if oauth, ok := client.auth.(*oauthTokenSource); ok {
+		// The Authenticator may be shared by several clients, so configure a copy rather than changing it.
+		tokenURL := oauth.tokenURL
+		if client.oauthTokenURL != "" {
+			tokenURL = client.oauthTokenURL
+		}
+		if tokenURL == "" {
+			tokenURL = OAuthTokenURL
+			if client.endpointURL == BaseURLEU {
+				tokenURL = OAuthTokenURLEU
+			}
+		}
+		client.auth = oauth.withClient(client.httpClient, tokenURL)
+	}
 	return &client
 }
 
 // WithBasicAuth configures the Airship Client to use HTTP Basic Auth
 // https://docs.airship.com/api/ua/#security-basicauth
 func WithBasicAuth(appKey, masterSecret string) ClientOption {
-	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(appKey+":"+masterSecret))
-	return func(c *uaHTTPClient) {
-		c.authHeader = authHeader
-	}
+	return WithAuthenticator(BasicAuthenticator(appKey, masterSecret))
 }
 
 // WithBearerAuth configures the Airship Client to use Bearer Auth
 // https://docs.airship.com/api/ua/#security-bearerauth
 func WithBearerAuth(token string) ClientOption {
-	authHeader := "Bearer " + token
+	return WithAuthenticator(BearerAuthenticator(token))
+}
+
+// Region is the Airship data center a project is hosted in.
+type Region string
+
+// Regions that can be passed to WithRegion.
+const (
+	RegionUS Region = BaseURL
+	RegionEU Region = BaseURLEU
+)
+
+// WithBaseURL overrides the base URL the endpoint paths are appended to, which defaults to BaseURL.
+func WithBaseURL(url string) ClientOption {
+	url = strings.TrimSuffix(url, "/")
+	return func(c *uaHTTPClient) {
+		c.endpointURL = url
+	}
+}
+
+// WithRegion configures the Airship Client to use the API servers of the given data center.
+// For example:
+//    conn := airship.New(airship.WithRegion(airship.RegionEU), airship.WithBasicAuth("app-key", "master-secret"))
+func WithRegion(region Region) ClientOption {
+	return WithBaseURL(string(region))
+}
+
+// WithDryRun configures the Airship Client to send every push request to the matching validation endpoint
+// instead, so payloads are fully checked by Airship but nothing is delivered. Creating or updating a schedule
+// validates the push of each schedule against EndpointValidatePush, and no schedule is saved, so the returned
+// ScheduleResponse has OK set but no schedule URLs or IDs. Any other request that would change the project, such as
+// deleting a schedule or setting tags, returns an error wrapping ErrDryRun without being sent.
+// Useful for staging environments.
+func WithDryRun() ClientOption {
 	return func(c *uaHTTPClient) {
-		c.authHeader = authHeader
+		c.dryRun = true
 	}
 }
 
@@ -90,28 +173,131 @@ func WithHTTPClient(httpClient *http.Client) ClientOption {
 }
 
 // InvokeEndpoint invokes the airship API endpoint by sending <body> to <endpoint> using HTTP <method>.
-// The response body is discarded unless an error status is returned.
+// <endpoint> is a path such as EndpointSendPush, or an absolute URL under the base URL such as a next_page link.
+// The response body is discarded unless an error status is returned, in which case the error is an *APIError.
 func (cfg *uaHTTPClient) InvokeEndpoint(method string, endpoint string, body interface{}) error {
-	jsonStr, err := json.Marshal(body)
-	if err != nil {
+	return cfg.Do(context.Background(), method, endpoint, body, nil)
+}
+
+// Do is like InvokeEndpoint, but the request is bound to <ctx> so it is abandoned when ctx is canceled or
+// its deadline passes. If <body> is nil no request body is sent, as for GET and DELETE requests.
+// Payloads with a Validate method, such as PushObject, and slices of them are validated first and not sent if
+// invalid.
+// On success the JSON response body is decoded into <out>, unless <out> is nil.
+func (cfg *uaHTTPClient) Do(ctx context.Context, method string, endpoint string, body interface{}, out interface{}) error {
+	if err := validateBody(body); err != nil {
 		return err
 	}
+	if cfg.dryRun {
+		pushes, isSchedule, err := schedulePushes(method, endpoint, body)
+		if err != nil {
+			return err
+		}
+		if isSchedule {
+			// Creating or updating a schedule would send its push later, so validate the push instead. The
+			// validation responses are decoded into out, so a ScheduleResponse reports OK but no schedules.
+			for _, push := range pushes {
+				if err := cfg.Do(ctx, http.MethodPost, EndpointValidatePush, push, out); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if validate, ok := validationEndpoints[endpoint]; ok && method == http.MethodPost {
+			endpoint = validate
+		}
+		if !isReadOnly(method, endpoint) {
+			return fmt.Errorf("%w: %s %s would change the project", ErrDryRun, method, endpoint)
+		}
+	}
+	var jsonStr []byte
+	if body != nil {
+		var err error
+		if jsonStr, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
 
-	req, err := http.NewRequest(method, cfg.endpointURL+endpoint, bytes.NewBuffer(jsonStr))
-	if err != nil {
-		return err
+	url := cfg.endpointURL + endpoint
+	if strings.HasPrefix(endpoint, "https://") || strings.HasPrefix(endpoint, "http://") {
+		// An absolute URL, such as a next_page link. Don't send credentials anywhere but the API server.
+		if !strings.HasPrefix(endpoint, cfg.endpointURL+"/") {
+			return fmt.Errorf("airship: refusing to send request to %s, which is not under %s", endpoint, cfg.endpointURL)
+		}
+		url = endpoint
 	}
-	req.Header.Add("Authorization", cfg.authHeader)
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Accept", AcceptHeader)
 
-	resp, err := cfg.httpClient.Do(req)
+	resp, err := cfg.send(ctx, method, url, jsonStr)
 	if err == nil {
 		defer resp.Body.Close()
-		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
 			respBody, _ := io.ReadAll(resp.Body)
-			return fmt.Errorf("airship: request returned %d: %s", resp.StatusCode, respBody)
+			return newAPIError(resp.StatusCode, respBody)
+		}
+		if out != nil {
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				return fmt.Errorf("airship: decoding response: %w", err)
+			}
 		}
 	}
 	return err
 }
+
+// send makes the HTTP request, repeating it as allowed by the retry policy.
+func (cfg *uaHTTPClient) send(ctx context.Context, method string, url string, jsonBody []byte) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := cfg.sendOnce(ctx, method, url, jsonBody)
+		if cfg.retry == nil || attempt >= cfg.retry.MaxAttempts || !isRetryable(resp, err) {
+			return resp, err
+		}
+		delay := cfg.retry.delay(attempt, resp)
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body) // Drain so the connection can be reused
+			resp.Body.Close()
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// sendOnce makes a single attempt at the HTTP request.
+func (cfg *uaHTTPClient) sendOnce(ctx context.Context, method string, url string, jsonBody []byte) (*http.Response, error) {
+	if cfg.limiter != nil {
+		if err := cfg.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	resp, req, err := cfg.sendAuthorized(ctx, method, url, jsonBody)
+	if auth, ok := cfg.auth.(refreshableAuthenticator); ok && err == nil && resp.StatusCode == http.StatusUnauthorized {
+		// The credentials may have been revoked before their expiry, so get fresh ones and try once more.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		auth.invalidate(req)
+		resp, _, err = cfg.sendAuthorized(ctx, method, url, jsonBody)
+	}
+	return resp, err
+}
+
+// sendAuthorized builds, authenticates and sends the HTTP request, returning the request along with the response.
+func (cfg *uaHTTPClient) sendAuthorized(ctx context.Context, method string, url string, jsonBody []byte) (*http.Response, *http.Request, error) {
+	var bodyReader io.Reader
+	if jsonBody != nil {
+		bodyReader = bytes.NewReader(jsonBody)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cfg.auth != nil {
+		if err := cfg.auth.Apply(ctx, req); err != nil {
+			return nil, nil, err
+		}
+	}
+	if jsonBody != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+	req.Header.Add("Accept", AcceptHeader)
+	resp, err := cfg.httpClient.Do(req)
+	return resp, req, err
+}
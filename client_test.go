@@ -1,11 +1,13 @@
 package airship
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/sean-rn/httpmock"
 	"github.com/stretchr/testify/assert"
@@ -67,6 +69,60 @@ func TestInvokeEndpoint_BearerToken(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestDo_DecodesResult(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"ok": true,"operation_id": "df6a6b50","push_ids": ["9d78a53b"],"message_ids": [], "content_urls": []}`))
+	})
+
+	testConnection := New(WithHTTPClient(client), WithBearerAuth(TestBearerToken))
+
+	// Invoke!
+	var result PushResponse
+	err := testConnection.Do(context.Background(), http.MethodPost, "/api/push", map[string]string{}, &result)
+	require.Nil(t, err)
+	assert.Equal(t, "df6a6b50", result.OperationID)
+	assert.Equal(t, []string{"9d78a53b"}, result.PushIDs)
+}
+
+// A request that outlives its context's deadline is abandoned with the context's error.
+func TestDo_ContextDeadline(t *testing.T) {
+	client := httpmock.NewTransportClient(func(req *http.Request) (*http.Response, error) {
+		<-req.Context().Done() // Simulate Airship never answering
+		return nil, req.Context().Err()
+	})
+
+	testConnection := New(WithBearerAuth(TestBearerToken), WithHTTPClient(client))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := testConnection.Do(ctx, http.MethodPost, "/api/push", map[string]string{}, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestInvokeEndpoint_BaseURL(t *testing.T) {
+	testCases := []struct {
+		name     string
+		option   ClientOption
+		expected string
+	}{
+		{name: "default", option: WithBearerAuth(TestBearerToken), expected: "https://go.urbanairship.com/api/push"},
+		{name: "US region", option: WithRegion(RegionUS), expected: "https://go.urbanairship.com/api/push"},
+		{name: "EU region", option: WithRegion(RegionEU), expected: "https://go.airship.eu/api/push"},
+		{name: "custom", option: WithBaseURL("http://localhost:8080/"), expected: "http://localhost:8080/api/push"},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, tt.expected, req.URL.String())
+			})
+			testConnection := New(WithHTTPClient(client), WithBearerAuth(TestBearerToken), tt.option)
+
+			err := testConnection.InvokeEndpoint(http.MethodPost, "/api/push", map[string]string{})
+			require.Nil(t, err)
+		})
+	}
+}
+
 // Make sure HTTP error codes returned by httpClient.Do() don't panic
 func TestInvokeEndpoint_HttpError(t *testing.T) {
 	assert := assert.New(t)
@@ -9,10 +9,11 @@ import (
 // CreateAndSend is a create-and-send request body for Urban Airship
 // https://docs.airship.com/api/ua/#operation-api-create-and-send-post
 // https://docs.airship.com/api/ua/#schemas-sms for the SMS varient
+// https://docs.airship.com/api/ua/#schemas-emailchannelobject for the email variant
 type CreateAndSend struct {
 	Audience     createAndSendAudience `json:"audience"`
 	Notification NotificationObject    `json:"notification"`
-	DeviceTypes  []string              `json:"device_types"`
+	DeviceTypes  []DeviceType          `json:"device_types"`
 }
 
 // CreateAndSendSMSTarget defines an audience target for where to send an SMS message
@@ -22,6 +23,23 @@ type CreateAndSendSMSTarget struct {
 	Sender  string    `json:"ua_sender"`   // The long or short code your SMS messages are sent from.
 }
 
+// CreateAndSendEmailTarget defines an audience target for where to send an email. Commercial emails are only
+// sent to addresses with CommercialOptedIn set.
+type CreateAndSendEmailTarget struct {
+	Address              string     `json:"ua_address"`
+	CommercialOptedIn    *time.Time `json:"ua_commercial_opted_in,omitempty"`    // When the address opted in to commercial email
+	TransactionalOptedIn *time.Time `json:"ua_transactional_opted_in,omitempty"` // When the address opted in to transactional email
+}
+
+// MarshalJSON formats the opt in times in UTC, as Airship expects.
+func (t CreateAndSendEmailTarget) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Address              string `json:"ua_address"`
+		CommercialOptedIn    string `json:"ua_commercial_opted_in,omitempty"`
+		TransactionalOptedIn string `json:"ua_transactional_opted_in,omitempty"`
+	}{t.Address, formatOptionalTime(t.CommercialOptedIn), formatOptionalTime(t.TransactionalOptedIn)})
+}
+
 // Intermediate struct to create the necessary wrapper object with "create_and_send" property around the acutal array.
 type createAndSendAudience struct {
 	CreateAndSend []createAndSendAudienceEntry `json:"create_and_send"`
@@ -29,7 +47,7 @@ type createAndSendAudience struct {
 
 // Entries in CreateAndSend audience combine the target channel info and template substitutions into one object.
 type createAndSendAudienceEntry struct {
-	target        interface{} // CreateAndSendSMSTarget
+	target        interface{} // CreateAndSendSMSTarget or CreateAndSendEmailTarget
 	substitutions map[string]string
 }
 
@@ -69,7 +87,7 @@ func MakeCreateAndSendSMSPayload(templateID string, subs map[string]string, shor
 
 	return &CreateAndSend{
 		Audience:    createAndSendAudience{CreateAndSend: audEntries},
-		DeviceTypes: []string{"sms"},
+		DeviceTypes: []DeviceType{DeviceTypeSMS},
 		Notification: NotificationObject{
 			Sms: &SMSOverrideWithTemplate{
 				Template:     &TemplateRef{TemplateID: templateID},
@@ -78,3 +96,27 @@ func MakeCreateAndSendSMSPayload(templateID string, subs map[string]string, shor
 		},
 	}, nil
 }
+
+// MakeCreateAndSendEmailPayload creates a new create-and-send payload to send an email to the recipients. The
+// content is given by email, either inline or with a template, and personalized with subs.
+func MakeCreateAndSendEmailPayload(email EmailOverrideWithTemplate, subs map[string]string, targets []CreateAndSendEmailTarget) (*CreateAndSend, error) {
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("airship: must specify at least one email destination")
+	}
+	audEntries := make([]createAndSendAudienceEntry, len(targets))
+	for i := range targets {
+		if email.MessageType == EmailMessageTypeCommercial && targets[i].CommercialOptedIn == nil {
+			return nil, fmt.Errorf("airship: %s must be opted in to commercial email", targets[i].Address)
+		}
+		audEntries[i] = createAndSendAudienceEntry{
+			target:        targets[i],
+			substitutions: subs,
+		}
+	}
+
+	return &CreateAndSend{
+		Audience:     createAndSendAudience{CreateAndSend: audEntries},
+		DeviceTypes:  []DeviceType{DeviceTypeEmail},
+		Notification: NotificationObject{Email: &email},
+	}, nil
+}
@@ -55,6 +55,60 @@ func TestNewCreateAndSendSMSPayload(t *testing.T) {
 	assert.JSONEq(t, expected, string(json))
 }
 
+func TestNewCreateAndSendEmailPayload(t *testing.T) {
+	const expected = `{
+		"audience": {
+			"create_and_send": [
+				{
+					"ua_address": "name@example.com",
+					"ua_transactional_opted_in": "2021-03-27T20:07:43",
+					"OrderID": "1234"
+				}
+			]
+		},
+		"device_types": [
+			"email"
+		],
+		"notification": {
+			"email": {
+				"subject": "Your receipt for order {{OrderID}}",
+				"html_body": "<p>Thanks for order {{OrderID}}</p>",
+				"plaintext_body": "Thanks for order {{OrderID}}",
+				"message_type": "transactional",
+				"sender_name": "Acme",
+				"sender_address": "receipts@example.com",
+				"reply_to": "support@example.com"
+			}
+		}
+	}`
+
+	optedIn := time.Date(2021, 3, 27, 13, 7, 43, 250, time.FixedZone("PDT", -7*60*60))
+	email := EmailOverrideWithTemplate{
+		Subject:       "Your receipt for order {{OrderID}}",
+		HTMLBody:      "<p>Thanks for order {{OrderID}}</p>",
+		PlaintextBody: "Thanks for order {{OrderID}}",
+		MessageType:   EmailMessageTypeTransactional,
+		SenderName:    "Acme",
+		SenderAddress: "receipts@example.com",
+		ReplyTo:       "support@example.com",
+	}
+	targets := []CreateAndSendEmailTarget{{Address: "name@example.com", TransactionalOptedIn: &optedIn}}
+
+	payload, err := MakeCreateAndSendEmailPayload(email, map[string]string{"OrderID": "1234"}, targets)
+	require.Nil(t, err)
+	assert.Nil(t, payload.Validate())
+	json, err := json.Marshal(&payload)
+	require.Nil(t, err)
+	assert.JSONEq(t, expected, string(json))
+
+	email.MessageType = EmailMessageTypeCommercial
+	_, err = MakeCreateAndSendEmailPayload(email, nil, targets)
+	assert.EqualError(t, err, "airship: name@example.com must be opted in to commercial email")
+
+	_, err = MakeCreateAndSendEmailPayload(email, nil, nil)
+	assert.EqualError(t, err, "airship: must specify at least one email destination")
+}
+
 func TestCreateAndSendAudienceEntry_MarshalJSON(t *testing.T) {
 	testCases := []struct {
 		name     string
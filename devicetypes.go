@@ -0,0 +1,177 @@
+package airship
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// DeviceType is a platform a push can be sent to, used in device_types.
+type DeviceType string
+
+// Device types supported by Airship. Open channel platforms are named with OpenDeviceType.
+const (
+	DeviceTypeIOS     DeviceType = "ios"
+	DeviceTypeAndroid DeviceType = "android"
+	DeviceTypeAmazon  DeviceType = "amazon"
+	DeviceTypeWeb     DeviceType = "web"
+	DeviceTypeWNS     DeviceType = "wns"
+	DeviceTypeEmail   DeviceType = "email"
+	DeviceTypeSMS     DeviceType = "sms"
+)
+
+const openDeviceTypePrefix = "open::"
+
+// OpenDeviceType returns the device type of an open channel platform, e.g. "open::kiosk" for "kiosk".
+func OpenDeviceType(platform string) DeviceType {
+	return DeviceType(openDeviceTypePrefix + platform)
+}
+
+// OpenPlatform returns the open channel platform of an "open::<platform>" device type.
+func (d DeviceType) OpenPlatform() (string, bool) {
+	if !strings.HasPrefix(string(d), openDeviceTypePrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(string(d), openDeviceTypePrefix), true
+}
+
+// MarshalJSON writes the open channel overrides as "open::<platform>" properties.
+func (n NotificationObject) MarshalJSON() ([]byte, error) {
+	type plain NotificationObject
+	data, err := json.Marshal(plain(n))
+	if err != nil || len(n.Open) == 0 {
+		return data, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for platform, override := range n.Open {
+		if fields[string(OpenDeviceType(platform))], err = json.Marshal(override); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON reads the "open::<platform>" properties into Open.
+func (n *NotificationObject) UnmarshalJSON(data []byte) error {
+	type plain NotificationObject
+	if err := json.Unmarshal(data, (*plain)(n)); err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for key, value := range fields {
+		platform, ok := DeviceType(key).OpenPlatform()
+		if !ok {
+			continue
+		}
+		var override OpenOverride
+		if err := json.Unmarshal(value, &override); err != nil {
+			return err
+		}
+		if n.Open == nil {
+			n.Open = map[string]*OpenOverride{}
+		}
+		n.Open[platform] = &override
+	}
+	return nil
+}
+
+// deviceTypes returns the device types the notification has overrides for.
+func (n *NotificationObject) deviceTypes() []DeviceType {
+	var types []DeviceType
+	for _, platform := range []struct {
+		deviceType DeviceType
+		present    bool
+	}{
+		{DeviceTypeIOS, n.IOS != nil},
+		{DeviceTypeAndroid, n.Android != nil},
+		{DeviceTypeAmazon, n.Amazon != nil},
+		{DeviceTypeWeb, n.Web != nil},
+		{DeviceTypeWNS, n.WNS != nil},
+		{DeviceTypeEmail, n.Email != nil},
+		{DeviceTypeSMS, n.Sms != nil},
+	} {
+		if platform.present {
+			types = append(types, platform.deviceType)
+		}
+	}
+	var open []string
+	for platform := range n.Open {
+		open = append(open, platform)
+	}
+	sort.Strings(open)
+	for _, platform := range open {
+		types = append(types, OpenDeviceType(platform))
+	}
+	return types
+}
+
+// newTemplateNotification creates a notification with overrides using the template for each of deviceTypes, except
+// email and WNS, which need more than a template.
+func newTemplateNotification(templateID string, deviceTypes ...DeviceType) NotificationObject {
+	template := func() *TemplateRef {
+		return &TemplateRef{TemplateID: templateID}
+	}
+	notif := NotificationObject{}
+	for _, deviceType := range deviceTypes {
+		switch deviceType {
+		case DeviceTypeIOS:
+			notif.IOS = &IOSOverrideWithTemplate{Template: template()}
+		case DeviceTypeAndroid:
+			notif.Android = &AndroidOverrideWithTemplate{Template: template()}
+		case DeviceTypeAmazon:
+			notif.Amazon = &AmazonOverrideWithTemplate{Template: template()}
+		case DeviceTypeWeb:
+			notif.Web = &WebOverrideWithTemplate{Template: template()}
+		case DeviceTypeSMS:
+			notif.Sms = &SMSOverrideWithTemplate{Template: template()}
+		default:
+			if platform, ok := deviceType.OpenPlatform(); ok {
+				if notif.Open == nil {
+					notif.Open = map[string]*OpenOverride{}
+				}
+				notif.Open[platform] = &OpenOverride{Template: template()}
+			}
+		}
+	}
+	return notif
+}
+
+// removeOverridesExcept removes the overrides of device types other than deviceTypes.
+func (n *NotificationObject) removeOverridesExcept(deviceTypes []DeviceType) {
+	wanted := map[DeviceType]bool{}
+	for _, deviceType := range deviceTypes {
+		wanted[deviceType] = true
+	}
+	if !wanted[DeviceTypeIOS] {
+		n.IOS = nil
+	}
+	if !wanted[DeviceTypeAndroid] {
+		n.Android = nil
+	}
+	if !wanted[DeviceTypeAmazon] {
+		n.Amazon = nil
+	}
+	if !wanted[DeviceTypeWeb] {
+		n.Web = nil
+	}
+	if !wanted[DeviceTypeWNS] {
+		n.WNS = nil
+	}
+	if !wanted[DeviceTypeEmail] {
+		n.Email = nil
+	}
+	if !wanted[DeviceTypeSMS] {
+		n.Sms = nil
+	}
+	for platform := range n.Open {
+		if !wanted[OpenDeviceType(platform)] {
+			delete(n.Open, platform)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package airship
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceType_OpenPlatform(t *testing.T) {
+	platform, ok := OpenDeviceType("kiosk").OpenPlatform()
+	assert.True(t, ok)
+	assert.Equal(t, "kiosk", platform)
+
+	_, ok = DeviceTypeWeb.OpenPlatform()
+	assert.False(t, ok)
+}
+
+func TestNotificationObject_OpenOverrides(t *testing.T) {
+	notification := NotificationObject{
+		Alert: "Hello",
+		Web:   &WebOverrideWithTemplate{Title: "Hi", Icon: &WebImage{URL: "https://example.com/icon.png"}, RequireInteraction: true},
+		Open: map[string]*OpenOverride{
+			"kiosk": {Alert: "Hello kiosk", MediaAttachment: &OpenMediaAttachment{Type: "image", URL: "https://example.com/a.png"}},
+		},
+	}
+	actual, err := json.Marshal(notification)
+	require.Nil(t, err)
+	assert.JSONEq(t, `{
+		"alert": "Hello",
+		"web": {"title": "Hi", "icon": {"url": "https://example.com/icon.png"}, "require_interaction": true},
+		"open::kiosk": {"alert": "Hello kiosk", "media_attachment": {"type": "image", "url": "https://example.com/a.png"}}
+	}`, string(actual))
+
+	var decoded NotificationObject
+	require.Nil(t, json.Unmarshal(actual, &decoded))
+	assert.Equal(t, notification, decoded)
+}
+
+func TestOverrides_MarshalJSON(t *testing.T) {
+	one := 1
+	notification := NotificationObject{
+		Amazon: &AmazonOverrideWithTemplate{Alert: "Hi", ConsolidationKey: "news", ExpiresAfter: ExpireAfter(time.Hour)},
+		WNS: &WNSOverride{
+			Toast: &WNSToast{Binding: &WNSBinding{Template: "ToastText01", Text: []string{"Hi"}}, Duration: "long"},
+			Badge: &WNSBadge{Value: &one},
+		},
+		Email: &EmailOverrideWithTemplate{
+			Subject:       "Welcome",
+			PlaintextBody: "Hello",
+			MessageType:   EmailMessageTypeTransactional,
+			SenderName:    "Acme",
+			SenderAddress: "hello@example.com",
+		},
+	}
+	actual, err := json.Marshal(notification)
+	require.Nil(t, err)
+	assert.JSONEq(t, `{
+		"amazon": {"alert": "Hi", "consolidation_key": "news", "expires_after": 3600},
+		"wns": {
+			"toast": {"binding": {"template": "ToastText01", "text": ["Hi"]}, "duration": "long"},
+			"badge": {"value": 1}
+		},
+		"email": {
+			"subject": "Welcome",
+			"plaintext_body": "Hello",
+			"message_type": "transactional",
+			"sender_name": "Acme",
+			"sender_address": "hello@example.com"
+		}
+	}`, string(actual))
+}
+
+func TestNewSendPushPayloadFor(t *testing.T) {
+	const expected = `{
+		"audience": {
+			"channel": ["channel-a"]
+		},
+		"notification": {
+			"android": {
+				"template": {
+					"template_id": "template-id-a"
+				},
+				"extra": {"key": "value"}
+			},
+			"web": {
+				"template": {
+					"template_id": "template-id-a"
+				},
+				"extra": {"key": "value"}
+			},
+			"open::kiosk": {
+				"template": {
+					"template_id": "template-id-a"
+				},
+				"extra": {"key": "value"}
+			}
+		},
+		"device_types": ["android", "web", "open::kiosk"]
+	}`
+	payload := MakeSendPushPayloadFor(templateIDA, []string{channelA}, nil,
+		[]DeviceType{DeviceTypeAndroid, DeviceTypeWeb, OpenDeviceType("kiosk")}, WithExtra(map[string]string{"key": "value"}))
+	actual, err := json.Marshal(&payload)
+	require.Nil(t, err)
+	assert.JSONEq(t, expected, string(actual))
+	assert.Nil(t, payload.Validate())
+}
+
+// Overrides of other device types set by the options are removed, and each option is applied once.
+func TestNewSendPushPayloadFor_RemovesOtherOverrides(t *testing.T) {
+	calls := 0
+	counter := func(notif *NotificationObject) { calls++ }
+	payload := MakeSendPushPayloadFor(templateIDA, []string{channelA}, nil, []DeviceType{DeviceTypeWeb},
+		WithExtra(map[string]string{"key": "value"}), WithOpenOverride("kiosk", OpenOverride{Alert: "Hi"}), counter)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, []DeviceType{DeviceTypeWeb}, payload.DeviceTypes)
+	assert.Nil(t, payload.Notification.IOS)
+	assert.Nil(t, payload.Notification.Android)
+	require.NotNil(t, payload.Notification.Web)
+	assert.Equal(t, map[string]string{"key": "value"}, payload.Notification.Web.Extra)
+	assert.Empty(t, payload.Notification.Open)
+}
+
+func TestNewSendPushPayloadFor_WithWNSOverride(t *testing.T) {
+	payload := MakeSendPushPayloadFor(templateIDA, []string{channelA}, nil, []DeviceType{DeviceTypeWNS},
+		WithWNSOverride(WNSOverride{Toast: &WNSToast{Binding: &WNSBinding{Template: "ToastText01", Text: []string{"Hi"}}}}))
+	assert.Equal(t, []DeviceType{DeviceTypeWNS}, payload.DeviceTypes)
+	require.NotNil(t, payload.Notification.WNS)
+	assert.Nil(t, payload.Validate())
+}
+
+func TestNewSendPushPayload_WithOpenOverride(t *testing.T) {
+	payload := MakeSendPushPayload(templateIDA, []string{channelA}, nil, WithOpenOverride("kiosk", OpenOverride{Alert: "Hi"}))
+	assert.Equal(t, []DeviceType{DeviceTypeIOS, DeviceTypeAndroid, OpenDeviceType("kiosk")}, payload.DeviceTypes)
+}
+
+func TestNewPushTemplatePayload_WithDeviceTypes(t *testing.T) {
+	payload := MakePushTemplatePayload(templateIDA, []string{channelA}, nil, DeviceTypeWeb, DeviceTypeAmazon)
+	assert.Equal(t, []DeviceType{DeviceTypeWeb, DeviceTypeAmazon}, payload.DeviceTypes)
+}
+
+// Email and WNS overrides aren't invented from the template, since they need more than it provides, so the
+// payload is invalid without them.
+func TestNewSendPushPayloadFor_EmailAndWNS(t *testing.T) {
+	payload := MakeSendPushPayloadFor(templateIDA, []string{channelA}, nil, []DeviceType{DeviceTypeAndroid, DeviceTypeEmail, DeviceTypeWNS})
+	assert.Equal(t, []DeviceType{DeviceTypeAndroid, DeviceTypeEmail, DeviceTypeWNS}, payload.DeviceTypes)
+	assert.Nil(t, payload.Notification.Email)
+	assert.Nil(t, payload.Notification.WNS)
+	assert.EqualError(t, payload.Validate(), "airship: invalid payload: "+
+		"missing required value on notification.email; "+
+		"missing override, and no alert to send instead on notification.wns")
+
+	email := EmailOverrideWithTemplate{
+		Template:      &TemplateRef{TemplateID: templateIDA},
+		MessageType:   EmailMessageTypeCommercial,
+		SenderName:    "Acme",
+		SenderAddress: "hello@example.com",
+	}
+	payload = MakeSendPushPayloadFor(templateIDA, []string{channelA}, nil, []DeviceType{DeviceTypeEmail}, WithEmailOverride(email))
+	assert.Equal(t, []DeviceType{DeviceTypeEmail}, payload.DeviceTypes)
+	assert.Nil(t, payload.Validate())
+}
+
+func TestEmailOverride_Validate(t *testing.T) {
+	payload := MakeSendPushPayloadFor(templateIDA, []string{channelA}, nil,
+		[]DeviceType{DeviceTypeEmail}, WithEmailOverride(EmailOverrideWithTemplate{Template: &TemplateRef{TemplateID: templateIDA}}))
+	assert.EqualError(t, payload.Validate(), "airship: invalid payload: "+
+		"missing required value on notification.email.message_type; "+
+		"missing required value on notification.email.sender_name; "+
+		"missing required value on notification.email.sender_address")
+}
@@ -0,0 +1,115 @@
+package airship
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// EmailChannel is an email address registered as a channel.
+// https://docs.airship.com/api/ua/#schemas-emailchannelobject
+type EmailChannel struct {
+	Address               string     `json:"address"`
+	OptInMode             string     `json:"opt_in_mode,omitempty"` // EmailOptInModeClassic or EmailOptInModeDouble, used by Register
+	Timezone              string     `json:"timezone,omitempty"`    // e.g. "America/Los_Angeles"
+	LocaleCountry         string     `json:"locale_country,omitempty"`
+	LocaleLanguage        string     `json:"locale_language,omitempty"`
+	CommercialOptedIn     *time.Time `json:"commercial_opted_in,omitempty"`
+	CommercialOptedOut    *time.Time `json:"commercial_opted_out,omitempty"`
+	TransactionalOptedIn  *time.Time `json:"transactional_opted_in,omitempty"`
+	TransactionalOptedOut *time.Time `json:"transactional_opted_out,omitempty"`
+}
+
+// Values of EmailChannel.OptInMode.
+const (
+	EmailOptInModeClassic = "classic" // The opt in dates are used as given
+	EmailOptInModeDouble  = "double"  // Airship sends an email asking the address to confirm it opts in
+)
+
+// emailChannelRequest is the body of the email register and update requests. OptInMode is only sent when
+// registering.
+type emailChannelRequest struct {
+	Channel   emailChannelBody `json:"channel"`
+	OptInMode string           `json:"opt_in_mode,omitempty"`
+}
+
+// emailChannelBody is an EmailChannel as sent to Airship, with its opt in times formatted by formatTime.
+type emailChannelBody struct {
+	Type                  string `json:"type"`
+	Address               string `json:"address"`
+	Timezone              string `json:"timezone,omitempty"`
+	LocaleCountry         string `json:"locale_country,omitempty"`
+	LocaleLanguage        string `json:"locale_language,omitempty"`
+	CommercialOptedIn     string `json:"commercial_opted_in,omitempty"`
+	CommercialOptedOut    string `json:"commercial_opted_out,omitempty"`
+	TransactionalOptedIn  string `json:"transactional_opted_in,omitempty"`
+	TransactionalOptedOut string `json:"transactional_opted_out,omitempty"`
+}
+
+func newEmailChannelRequest(c EmailChannel) *emailChannelRequest {
+	return &emailChannelRequest{Channel: emailChannelBody{
+		Type:                  string(DeviceTypeEmail),
+		Address:               c.Address,
+		Timezone:              c.Timezone,
+		LocaleCountry:         c.LocaleCountry,
+		LocaleLanguage:        c.LocaleLanguage,
+		CommercialOptedIn:     formatOptionalTime(c.CommercialOptedIn),
+		CommercialOptedOut:    formatOptionalTime(c.CommercialOptedOut),
+		TransactionalOptedIn:  formatOptionalTime(c.TransactionalOptedIn),
+		TransactionalOptedOut: formatOptionalTime(c.TransactionalOptedOut),
+	}}
+}
+
+// Validate checks that the address is set and the opt in mode is known.
+func (r emailChannelRequest) Validate() error {
+	v := fieldValidator{}
+	v.required("channel.address", r.Channel.Address != "")
+	switch r.OptInMode {
+	case "", EmailOptInModeClassic, EmailOptInModeDouble:
+	default:
+		v.fail("opt_in_mode", fmt.Sprintf("unknown opt in mode %q", r.OptInMode))
+	}
+	return v.err()
+}
+
+// emailUninstallRequest is the body of the email uninstall request.
+type emailUninstallRequest struct {
+	EmailAddress string `json:"email_address"`
+}
+
+// EmailService invokes the Airship email channel endpoints through a Client.
+type EmailService struct {
+	client Client
+}
+
+// NewEmailService creates an EmailService that sends its requests using client.
+func NewEmailService(client Client) *EmailService {
+	return &EmailService{client: client}
+}
+
+// Register registers an email address, or updates it if it's already registered, returning its channel ID.
+// https://docs.airship.com/api/ua/#operation-api-channels-email-post
+func (s *EmailService) Register(ctx context.Context, channel EmailChannel) (string, error) {
+	body := newEmailChannelRequest(channel)
+	body.OptInMode = channel.OptInMode
+	var resp channelRegistrationResponse
+	if err := s.client.Do(ctx, http.MethodPost, EndpointEmailChannels, body, &resp); err != nil {
+		return "", err
+	}
+	return resp.ChannelID, nil
+}
+
+// Update replaces the address, opt in dates and locale of an email channel. The opt in mode can't be changed.
+// https://docs.airship.com/api/ua/#operation-api-channels-email-channel_id-put
+func (s *EmailService) Update(ctx context.Context, channelID string, channel EmailChannel) error {
+	return s.client.Do(ctx, http.MethodPut, EndpointEmailChannels+"/"+url.PathEscape(channelID), newEmailChannelRequest(channel), nil)
+}
+
+// Uninstall removes an email address, so it no longer receives any email.
+// https://docs.airship.com/api/ua/#operation-api-channels-email-uninstall-post
+func (s *EmailService) Uninstall(ctx context.Context, address string) error {
+	body := emailUninstallRequest{EmailAddress: address}
+	return s.client.Do(ctx, http.MethodPost, EndpointEmailChannels+"/uninstall", &body, nil)
+}
@@ -0,0 +1,92 @@
+package airship
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sean-rn/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailService_Register(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "POST", req.Method)
+		assert.Equal(t, "https://go.urbanairship.com/api/channels/email", req.URL.String())
+		assertBodyJSONEqual(t, `{
+			"channel": {
+				"type": "email",
+				"address": "name@example.com",
+				"timezone": "America/Los_Angeles",
+				"transactional_opted_in": "2021-04-02T16:00:00"
+			},
+			"opt_in_mode": "double"
+		}`, req.Body)
+		rw.WriteHeader(http.StatusCreated)
+		rw.Write([]byte(`{"ok": true, "channel_id": "email-channel-1"}`))
+	})
+	service := NewEmailService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	optedIn := time.Date(2021, 4, 2, 9, 0, 0, 500, time.FixedZone("PDT", -7*60*60))
+	channelID, err := service.Register(context.Background(), EmailChannel{
+		Address:              "name@example.com",
+		OptInMode:            EmailOptInModeDouble,
+		Timezone:             "America/Los_Angeles",
+		TransactionalOptedIn: &optedIn,
+	})
+	require.Nil(t, err)
+	assert.Equal(t, "email-channel-1", channelID)
+}
+
+func TestEmailService_UpdateUninstall(t *testing.T) {
+	ctx := context.Background()
+	optedOut := time.Date(2021, 4, 2, 18, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	testCases := []struct {
+		name         string
+		method       string
+		url          string
+		expectedBody string
+		invoke       func(s *EmailService) error
+	}{
+		{
+			name:         "update",
+			method:       "PUT",
+			url:          "https://go.urbanairship.com/api/channels/email/email-channel-1",
+			expectedBody: `{"channel": {"type": "email", "address": "name@example.com", "commercial_opted_out": "2021-04-02T16:00:00"}}`,
+			invoke: func(s *EmailService) error {
+				return s.Update(ctx, "email-channel-1", EmailChannel{Address: "name@example.com", OptInMode: EmailOptInModeDouble, CommercialOptedOut: &optedOut})
+			},
+		},
+		{
+			name:         "uninstall",
+			method:       "POST",
+			url:          "https://go.urbanairship.com/api/channels/email/uninstall",
+			expectedBody: `{"email_address": "name@example.com"}`,
+			invoke:       func(s *EmailService) error { return s.Uninstall(ctx, "name@example.com") },
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, tt.method, req.Method)
+				assert.Equal(t, tt.url, req.URL.String())
+				assertBodyJSONEqual(t, tt.expectedBody, req.Body)
+				rw.Write([]byte(`{"ok": true}`))
+			})
+			service := NewEmailService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+			assert.Nil(t, tt.invoke(service))
+		})
+	}
+}
+
+func TestEmailService_Register_Validates(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		t.Error("no request should have been sent")
+	})
+	service := NewEmailService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	_, err := service.Register(context.Background(), EmailChannel{OptInMode: "single"})
+	assert.EqualError(t, err, "airship: invalid payload: missing required value on channel.address; unknown opt in mode \"single\" on opt_in_mode")
+}
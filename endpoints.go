@@ -1,17 +1,127 @@
 package airship
 
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// validationEndpoints maps each endpoint that sends pushes to the endpoint that validates the same payload.
+var validationEndpoints = map[string]string{
+	EndpointSendPush:       EndpointValidatePush,
+	EndpointPushToTemplate: EndpointValidatePushToTemplate,
+	EndpointCreateAndSend:  EndpointValidateCreateAndSend,
+}
+
+// isReadOnly reports whether a request only reads from or validates against the project, so it's safe in dry run.
+func isReadOnly(method, endpoint string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	case http.MethodPost:
+		for _, validate := range validationEndpoints {
+			if endpoint == validate {
+				return true
+			}
+		}
+		return strings.HasPrefix(endpoint, EndpointTemplates+"/") && strings.HasSuffix(endpoint, "/preview")
+	}
+	return false
+}
+
+// schedulePushes returns the pushes of the schedules in body when the request creates or updates schedules.
+func schedulePushes(method, endpoint string, body interface{}) ([]PushObject, bool, error) {
+	creates := method == http.MethodPost && endpoint == EndpointSchedules
+	updates := method == http.MethodPut && strings.HasPrefix(endpoint, EndpointSchedules+"/")
+	if !creates && !updates {
+		return nil, false, nil
+	}
+	switch b := body.(type) {
+	case []Schedule:
+		pushes := make([]PushObject, len(b))
+		for i := range b {
+			pushes[i] = b[i].Push
+		}
+		return pushes, true, nil
+	case Schedule:
+		return []PushObject{b.Push}, true, nil
+	case *Schedule:
+		return []PushObject{b.Push}, true, nil
+	}
+	return nil, true, fmt.Errorf("%w: can't validate the pushes of a %T schedule body", ErrDryRun, body)
+}
+
+// PushService invokes the Airship push endpoints through a Client.
+type PushService struct {
+	client Client
+}
+
+// NewPushService creates a PushService that sends its requests using client.
+func NewPushService(client Client) *PushService {
+	return &PushService{client: client}
+}
+
+// PushResponse is the body returned by Airship when a push request is accepted.
+// https://docs.airship.com/api/ua/#schemas-pushresponse
+type PushResponse struct {
+	OK          bool     `json:"ok"`
+	OperationID string   `json:"operation_id"`           // Identifies the whole API call, quote it when contacting Airship support.
+	PushIDs     []string `json:"push_ids"`               // One ID per push object sent, in the same order.
+	MessageIDs  []string `json:"message_ids,omitempty"`  // Message Center message IDs, if any.
+	ContentURLs []string `json:"content_urls,omitempty"` // Message Center content URLs, if any.
+}
+
 // PushToTemplate invokes the Airship "Push to Template" API
 // https://docs.airship.com/api/ua/#operation-api-templates-push-post
-func PushToTemplate(templateID string, channels []string, substitutions map[string]string) {
+func (s *PushService) PushToTemplate(ctx context.Context, templateID string, channels []string, substitutions map[string]string) (*PushResponse, error) {
 	body := MakePushTemplatePayload(templateID, channels, substitutions)
-	_ = body
-	// return cfg.invokeEndpoint(http.Post(), "/api/templates/push", &ua)
+	return s.post(ctx, EndpointPushToTemplate, &body)
 }
 
 // SendPush invokes the Airship "Send a Push" API
 // https://docs.airship.com/api/ua/#operation-api-push-post
-func SendPush(templateID string, channels []string, substitutions map[string]string) {
-	body := MakeSendPushPayload(templateID, channels, substitutions)
-	_ = body
-	// return cfg.invokeEndpoint(http.Post(), "/api/push", &ua)
+func (s *PushService) SendPush(ctx context.Context, templateID string, channels []string, substitutions map[string]string, options ...PushNotificationOption) (*PushResponse, error) {
+	body := MakeSendPushPayload(templateID, channels, substitutions, options...)
+	return s.post(ctx, EndpointSendPush, &body)
+}
+
+// Send invokes the Airship "Send a Push" API with a payload such as one from MakeSendPushPayloadFor.
+// https://docs.airship.com/api/ua/#operation-api-push-post
+func (s *PushService) Send(ctx context.Context, payload *PushObject) (*PushResponse, error) {
+	return s.post(ctx, EndpointSendPush, payload)
+}
+
+// CreateAndSend invokes the Airship "Create and Send" API with a payload such as one from MakeCreateAndSendSMSPayload.
+// https://docs.airship.com/api/ua/#operation-api-create-and-send-post
+func (s *PushService) CreateAndSend(ctx context.Context, payload *CreateAndSend) (*PushResponse, error) {
+	return s.post(ctx, EndpointCreateAndSend, payload)
+}
+
+// ValidatePush asks Airship to validate a PushObject or CreateAndSend payload without sending it.
+// The payload is first checked locally, returning ValidationErrors if that fails. If Airship finds it invalid, the
+// error is an *APIError describing the problem just as a real send would.
+// https://docs.airship.com/api/ua/#operation-api-push-validate-post
+func (s *PushService) ValidatePush(ctx context.Context, payload interface{}) error {
+	var endpoint string
+	switch payload.(type) {
+	case PushObject, *PushObject, []PushObject:
+		endpoint = EndpointValidatePush
+	case PushTemplatePayload, *PushTemplatePayload, []PushTemplatePayload:
+		endpoint = EndpointValidatePushToTemplate
+	case CreateAndSend, *CreateAndSend:
+		endpoint = EndpointValidateCreateAndSend
+	default:
+		return fmt.Errorf("airship: cannot validate payload of type %T", payload)
+	}
+	return s.client.Do(ctx, http.MethodPost, endpoint, payload, nil)
+}
+
+// post sends body to endpoint and decodes the response.
+func (s *PushService) post(ctx context.Context, endpoint string, body interface{}) (*PushResponse, error) {
+	var resp PushResponse
+	if err := s.client.Do(ctx, http.MethodPost, endpoint, body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
 }
@@ -0,0 +1,258 @@
+package airship
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sean-rn/go-airship/audience"
+	"github.com/sean-rn/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// This suite tests the PushService, verifying each method posts its payload to the right endpoint.
+
+func TestPushService_PushToTemplate(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedBody := `{
+		"audience": { "channel": ["channel-a"] },
+		"device_types": ["ios", "android"],
+		"merge_data": {
+			"substitutions": { "ActivityID": "2342" },
+			"template_id": "template-id-a"
+		}
+	}`
+
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal("POST", req.Method)
+		assert.Equal("https://go.urbanairship.com/api/templates/push", req.URL.String())
+		assertBodyJSONEqual(t, expectedBody, req.Body)
+		rw.WriteHeader(http.StatusAccepted)
+		rw.Write([]byte(`{"ok": true,"operation_id": "df6a6b50","push_ids": ["9d78a53b"]}`))
+	})
+	service := NewPushService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	resp, err := service.PushToTemplate(context.Background(), templateIDA, []string{channelA}, map[string]string{"ActivityID": "2342"})
+	require.Nil(t, err)
+	assert.True(resp.OK)
+	assert.Equal("df6a6b50", resp.OperationID)
+	assert.Equal([]string{"9d78a53b"}, resp.PushIDs)
+}
+
+func TestPushService_SendPush(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedBody := `{
+		"audience": { "channel": ["channel-a"] },
+		"global_attributes": { "ActivityID": "2402" },
+		"notification": {
+			"ios": { "template": { "template_id": "template-id-a" } },
+			"android": { "template": { "template_id": "template-id-a" } },
+			"actions": { "open": { "type": "url", "content": "https://xkcd.com/{{ActivityID}}" } }
+		},
+		"device_types": ["ios", "android"]
+	}`
+
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal("POST", req.Method)
+		assert.Equal("https://go.urbanairship.com/api/push", req.URL.String())
+		assertBodyJSONEqual(t, expectedBody, req.Body)
+		rw.WriteHeader(http.StatusAccepted)
+		rw.Write([]byte(`{"ok": true,"operation_id": "df6a6b50","push_ids": ["9d78a53b"],"message_ids": ["m-1"], "content_urls": ["https://dl.urbanairship.com/m-1"]}`))
+	})
+	service := NewPushService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	resp, err := service.SendPush(context.Background(), templateIDA, []string{channelA}, map[string]string{"ActivityID": "2402"},
+		WithOpenURLAction("https://xkcd.com/{{ActivityID}}"))
+	require.Nil(t, err)
+	assert.Equal(&PushResponse{
+		OK:          true,
+		OperationID: "df6a6b50",
+		PushIDs:     []string{"9d78a53b"},
+		MessageIDs:  []string{"m-1"},
+		ContentURLs: []string{"https://dl.urbanairship.com/m-1"},
+	}, resp)
+}
+
+func TestPushService_CreateAndSend(t *testing.T) {
+	assert := assert.New(t)
+
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal("POST", req.Method)
+		assert.Equal("https://go.urbanairship.com/api/create-and-send", req.URL.String())
+		rw.WriteHeader(http.StatusAccepted)
+		rw.Write([]byte(`{"ok": true,"operation_id": "efb18e92","push_ids": ["a1", "b2"]}`))
+	})
+	service := NewPushService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	payload, err := MakeCreateAndSendSMSPayload(templateIDA, nil, false, []CreateAndSendSMSTarget{{MSISDN: "19785551212", Sender: "12062071886"}})
+	require.Nil(t, err)
+	resp, err := service.CreateAndSend(context.Background(), payload)
+	require.Nil(t, err)
+	assert.Equal("efb18e92", resp.OperationID)
+	assert.Equal([]string{"a1", "b2"}, resp.PushIDs)
+}
+
+func TestPushService_Send(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "POST", req.Method)
+		assert.Equal(t, "https://go.urbanairship.com/api/push", req.URL.String())
+		body, err := io.ReadAll(req.Body)
+		require.Nil(t, err)
+		assert.JSONEq(t, `{
+			"audience": {"channel": ["channel-a"]},
+			"device_types": ["web"],
+			"notification": {"web": {"template": {"template_id": "template-id-a"}}}
+		}`, string(body))
+		rw.WriteHeader(http.StatusAccepted)
+		rw.Write([]byte(`{"ok": true, "operation_id": "efb18e92", "push_ids": ["a1"]}`))
+	})
+	service := NewPushService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	payload := MakeSendPushPayloadFor(templateIDA, []string{channelA}, nil, []DeviceType{DeviceTypeWeb})
+	resp, err := service.Send(context.Background(), &payload)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"a1"}, resp.PushIDs)
+}
+
+// A success status with a body that is not JSON is reported as an error.
+func TestPushService_SendPush_BadResponseBody(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`<html>Gateway</html>`))
+	})
+	service := NewPushService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	resp, err := service.SendPush(context.Background(), templateIDA, []string{channelA}, nil)
+	assert.Error(t, err)
+	assert.Nil(t, resp)
+}
+
+func TestPushService_SendPush_HttpError(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		rw.Write([]byte(`{"ok": false, "error": "Could not parse request body."}`))
+	})
+	service := NewPushService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	resp, err := service.SendPush(context.Background(), templateIDA, []string{channelA}, nil)
+	assert.Error(t, err)
+	assert.Nil(t, resp)
+}
+
+// The context passed to the service is carried through to the HTTP request.
+func TestPushService_SendPush_CanceledContext(t *testing.T) {
+	client := httpmock.NewTransportClient(func(req *http.Request) (*http.Response, error) {
+		return nil, req.Context().Err()
+	})
+	service := NewPushService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := service.SendPush(ctx, templateIDA, []string{channelA}, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestPushService_ValidatePush(t *testing.T) {
+	payload, err := MakeCreateAndSendSMSPayload(templateIDA, nil, false, []CreateAndSendSMSTarget{{MSISDN: "19785551212", Sender: "12062071886"}})
+	require.Nil(t, err)
+
+	testCases := []struct {
+		name     string
+		payload  interface{}
+		expected string
+	}{
+		{name: "push object", payload: MakeSendPushPayload(templateIDA, []string{channelA}, nil), expected: "https://go.urbanairship.com/api/push/validate"},
+		{name: "push object pointer", payload: &PushObject{Audience: audience.All(), DeviceTypes: "all"}, expected: "https://go.urbanairship.com/api/push/validate"},
+		{name: "push template", payload: MakePushTemplatePayload(templateIDA, []string{channelA}, nil), expected: "https://go.urbanairship.com/api/templates/push/validate"},
+		{name: "create and send", payload: payload, expected: "https://go.urbanairship.com/api/create-and-send/validate"},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, "POST", req.Method)
+				assert.Equal(t, tt.expected, req.URL.String())
+				rw.Write([]byte(`{"ok": true}`))
+			})
+			service := NewPushService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+			assert.Nil(t, service.ValidatePush(context.Background(), tt.payload))
+		})
+	}
+}
+
+func TestPushService_ValidatePush_Invalid(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		rw.Write([]byte(`{"ok": false, "error": "Could not parse request body.", "error_code": 40001, "details": {"path": "audience"}}`))
+	})
+	service := NewPushService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	err := service.ValidatePush(context.Background(), MakeSendPushPayload(templateIDA, []string{channelA}, nil))
+	assert.True(t, IsValidationError(err))
+
+	err = service.ValidatePush(context.Background(), "not a payload")
+	assert.EqualError(t, err, "airship: cannot validate payload of type string")
+}
+
+func TestPushService_DryRun(t *testing.T) {
+	var received []string
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		received = append(received, req.Method+" "+req.URL.Path)
+		rw.Write([]byte(`{"ok": true}`))
+	})
+	conn := New(WithHTTPClient(client), WithBearerAuth(TestBearerToken), WithDryRun())
+	service := NewPushService(conn)
+	schedules := NewScheduleService(conn)
+	ctx := context.Background()
+	schedule := Schedule{
+		Schedule: ScheduleAt(time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)),
+		Push:     MakeSendPushPayload(templateIDA, []string{channelA}, nil),
+	}
+
+	_, err := service.SendPush(ctx, templateIDA, []string{channelA}, nil)
+	require.Nil(t, err)
+	_, err = service.PushToTemplate(ctx, templateIDA, []string{channelA}, nil)
+	require.Nil(t, err)
+	// Scheduling validates each schedule's push instead of saving the schedules
+	resp, err := schedules.Create(ctx, schedule, schedule)
+	require.Nil(t, err)
+	assert.True(t, resp.OK)
+	assert.Empty(t, resp.ScheduleIDs)
+	_, err = schedules.Update(ctx, "schedule-1", schedule)
+	require.Nil(t, err)
+	// Reading and validating are unaffected
+	require.Nil(t, service.ValidatePush(ctx, schedule.Push))
+	_, err = schedules.Get(ctx, "schedule-1")
+	require.Nil(t, err)
+
+	assert.Equal(t, []string{
+		"POST /api/push/validate",
+		"POST /api/templates/push/validate",
+		"POST /api/push/validate",
+		"POST /api/push/validate",
+		"POST /api/push/validate",
+		"POST /api/push/validate",
+		"GET /api/schedules/schedule-1",
+	}, received)
+
+	// Schedule bodies whose pushes can't be found are refused rather than sent
+	err = conn.InvokeEndpoint(http.MethodPost, EndpointSchedules, []map[string]interface{}{{"name": "raw"}})
+	assert.EqualError(t, err, "airship: refused in dry run: can't validate the pushes of a []map[string]interface {} schedule body")
+	assert.ErrorIs(t, err, ErrDryRun)
+
+	// Other changes to the project are refused rather than sent
+	err = schedules.Delete(ctx, "abc")
+	assert.ErrorIs(t, err, ErrDryRun)
+	assert.EqualError(t, err, "airship: refused in dry run: DELETE /api/schedules/abc would change the project")
+	err = schedules.Pause(ctx, "abc")
+	assert.EqualError(t, err, "airship: refused in dry run: POST /api/schedules/abc/pause would change the project")
+	_, err = NewChannelService(conn).UpdateTags(ctx, ChannelAudience{Channels: []string{channelA}}, TagMutation{Add: map[string][]string{"group": {"tag"}}})
+	assert.EqualError(t, err, "airship: refused in dry run: POST /api/channels/tags would change the project")
+	err = NewSMSService(conn).OptOut(ctx, CreateAndSendSMSTarget{MSISDN: "15035556789", Sender: "12345"})
+	assert.EqualError(t, err, "airship: refused in dry run: POST /api/channels/sms/opt-out would change the project")
+	assert.Len(t, received, 7)
+}
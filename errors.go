@@ -0,0 +1,84 @@
+package airship
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrDryRun is wrapped by the errors returned for requests that a Client configured WithDryRun refuses to send
+// because they would change the project. Use errors.Is to check for it.
+var ErrDryRun = errors.New("airship: refused in dry run")
+
+// APIError is returned when Airship responds with an error status.
+// https://docs.airship.com/api/ua/#schemas-errorresponse
+// Use errors.As to retrieve it from an error returned by the Client.
+type APIError struct {
+	StatusCode  int           `json:"-"`            // The HTTP status code of the response.
+	OperationID string        `json:"operation_id"` // Identifies the API call when contacting Airship support.
+	Message     string        `json:"error"`        // Human readable description of the error.
+	ErrorCode   int           `json:"error_code"`   // Airship specific error code, e.g. 40001.
+	Details     *ErrorDetails `json:"details,omitempty"`
+	Body        []byte        `json:"-"` // The raw response body.
+}
+
+// ErrorDetails points to the part of the request that caused an error.
+type ErrorDetails struct {
+	Error    string         `json:"error,omitempty"`
+	Path     string         `json:"path,omitempty"` // Path to the offending field, e.g. "notification.ios.badge".
+	Location *ErrorLocation `json:"location,omitempty"`
+}
+
+// ErrorLocation is the position in the request body JSON where a parse error happened.
+type ErrorLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// newAPIError builds an APIError from an error response, keeping the raw body if it isn't Airship's JSON format.
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := APIError{}
+	_ = json.Unmarshal(body, &apiErr) // Not all errors (e.g. from proxies) have a JSON body
+	apiErr.StatusCode = statusCode
+	apiErr.Body = body
+	return &apiErr
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("airship: request returned %d: %s", e.StatusCode, e.Body)
+	}
+	msg := fmt.Sprintf("airship: request returned %d: %s", e.StatusCode, e.Message)
+	if e.ErrorCode != 0 {
+		msg += fmt.Sprintf(" (error_code %d)", e.ErrorCode)
+	}
+	if e.Details != nil && e.Details.Path != "" {
+		msg += " at " + e.Details.Path
+	}
+	return msg
+}
+
+// IsRateLimited reports whether err is an APIError for a request rejected with 429 Too Many Requests.
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// IsUnauthorized reports whether err is an APIError for a request rejected with 401 Unauthorized,
+// which usually means the credentials are wrong or expired.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsValidationError reports whether err is an APIError for a request rejected with 400 Bad Request,
+// meaning Airship could not parse or validate the payload, or ValidationErrors from checking it locally.
+func IsValidationError(err error) bool {
+	var fieldErrs ValidationErrors
+	return hasStatus(err, http.StatusBadRequest) || errors.As(err, &fieldErrs)
+}
+
+func hasStatus(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
@@ -0,0 +1,91 @@
+package airship
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/sean-rn/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvokeEndpoint_APIError(t *testing.T) {
+	assert := assert.New(t)
+
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		rw.Write([]byte(`{
+			"ok": false,
+			"operation_id": "8c61c0a4-95b0-11e4-a4be-001b21a8c2c9",
+			"error": "Could not parse request body.",
+			"error_code": 40001,
+			"details": {
+				"error": "Unrecognized field \"badg\"",
+				"path": "notification.ios.badg",
+				"location": { "line": 12, "column": 9 }
+			}
+		}`))
+	})
+
+	testConnection := New(WithBearerAuth(TestBearerToken), WithHTTPClient(client))
+	err := testConnection.InvokeEndpoint(http.MethodPost, "/api/push", map[string]string{})
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal("8c61c0a4-95b0-11e4-a4be-001b21a8c2c9", apiErr.OperationID)
+	assert.Equal("Could not parse request body.", apiErr.Message)
+	assert.Equal(40001, apiErr.ErrorCode)
+	assert.Equal(&ErrorDetails{
+		Error:    `Unrecognized field "badg"`,
+		Path:     "notification.ios.badg",
+		Location: &ErrorLocation{Line: 12, Column: 9},
+	}, apiErr.Details)
+	assert.Equal("airship: request returned 400: Could not parse request body. (error_code 40001) at notification.ios.badg", err.Error())
+	assert.True(IsValidationError(err))
+	assert.False(IsRateLimited(err))
+	assert.False(IsUnauthorized(err))
+}
+
+// Error bodies that aren't Airship JSON (e.g. from a load balancer) are kept raw.
+func TestInvokeEndpoint_APIError_NotJSON(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadGateway)
+		rw.Write([]byte(`<html>Bad Gateway</html>`))
+	})
+
+	testConnection := New(WithBearerAuth(TestBearerToken), WithHTTPClient(client))
+	err := testConnection.InvokeEndpoint(http.MethodPost, "/api/push", map[string]string{})
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+	assert.Equal(t, "airship: request returned 502: <html>Bad Gateway</html>", err.Error())
+}
+
+func TestAPIErrorPredicates(t *testing.T) {
+	testCases := []struct {
+		name          string
+		err           error
+		rateLimited   bool
+		unauthorized  bool
+		validationErr bool
+	}{
+		{name: "rate limited", err: &APIError{StatusCode: 429}, rateLimited: true},
+		{name: "unauthorized", err: &APIError{StatusCode: 401}, unauthorized: true},
+		{name: "validation", err: &APIError{StatusCode: 400}, validationErr: true},
+		{name: "wrapped", err: fmt.Errorf("sending push: %w", &APIError{StatusCode: 429}), rateLimited: true},
+		{name: "server error", err: &APIError{StatusCode: 500}},
+		{name: "not an APIError", err: fmt.Errorf("oh no an error")},
+		{name: "nil", err: nil},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.rateLimited, IsRateLimited(tt.err))
+			assert.Equal(t, tt.unauthorized, IsUnauthorized(tt.err))
+			assert.Equal(t, tt.validationErr, IsValidationError(tt.err))
+		})
+	}
+}
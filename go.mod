@@ -1,8 +1,15 @@
 module github.com/sean-rn/go-airship
 
-go 1.16
+go 1.18
 
 require (
 	github.com/sean-rn/httpmock v0.0.1
 	github.com/stretchr/testify v1.7.0
 )
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/stretchr/objx v0.1.0 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
+)
@@ -0,0 +1,237 @@
+package airship
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IOSOverrideWithTemplate https://docs.airship.com/api/ua/#schemas-iosoverridewithtemplate
+type IOSOverrideWithTemplate struct {
+	Template          *TemplateRef        `json:"template,omitempty"`
+	Alert             *IOSAlert           `json:"alert,omitempty"`
+	Actions           *Actions            `json:"actions,omitempty"`
+	Extra             map[string]string   `json:"extra,omitempty"`
+	Sound             string              `json:"sound,omitempty"`
+	Badge             IOSBadge            `json:"badge,omitempty"`
+	CollapseID        string              `json:"collapse_id,omitempty"`
+	Category          string              `json:"category,omitempty"`
+	Title             string              `json:"title,omitempty"`
+	Subtitle          string              `json:"subtitle,omitempty"`
+	MutableContent    bool                `json:"mutable_content,omitempty"`   // Lets a notification service extension modify the notification
+	ContentAvailable  bool                `json:"content_available,omitempty"` // Wakes the app in the background
+	MediaAttachment   *IOSMediaAttachment `json:"media_attachment,omitempty"`
+	InterruptionLevel string              `json:"interruption_level,omitempty"` // One of the InterruptionLevel* constants
+	RelevanceScore    float64             `json:"relevance_score,omitempty"`    // Between 0 and 1, ranks notifications in the summary
+	ThreadID          string              `json:"thread_id,omitempty"`          // Groups notifications in Notification Center
+	TargetContentID   string              `json:"target_content_id,omitempty"`  // Window brought forward when the notification is opened
+	Expiry            *Expiry             `json:"expiry,omitempty"`
+	Priority          int                 `json:"priority,omitempty"`      // IOSPriorityHigh or IOSPriorityNormal
+	LiveActivity      *IOSLiveActivity    `json:"live_activity,omitempty"` // Updates or ends a Live Activity
+}
+
+// Values of IOSOverrideWithTemplate.InterruptionLevel.
+const (
+	InterruptionLevelPassive       = "passive"
+	InterruptionLevelActive        = "active"
+	InterruptionLevelTimeSensitive = "time-sensitive"
+	InterruptionLevelCritical      = "critical"
+)
+
+// Values of IOSOverrideWithTemplate.Priority and IOSLiveActivity.Priority.
+const (
+	IOSPriorityNormal = 5  // Delivered at a time that conserves power
+	IOSPriorityHigh   = 10 // Delivered immediately
+)
+
+// IOSAlert is the object form of the iOS alert, for localized and titled alerts. A plain string alert is
+// decoded into Body.
+// https://docs.airship.com/api/ua/#schemas-iosalertobject
+type IOSAlert struct {
+	Body            string   `json:"body,omitempty"`
+	Title           string   `json:"title,omitempty"`
+	Subtitle        string   `json:"subtitle,omitempty"`
+	ActionLocKey    string   `json:"action-loc-key,omitempty"`
+	LocKey          string   `json:"loc-key,omitempty"`
+	LocArgs         []string `json:"loc-args,omitempty"`
+	TitleLocKey     string   `json:"title-loc-key,omitempty"`
+	TitleLocArgs    []string `json:"title-loc-args,omitempty"`
+	SubtitleLocKey  string   `json:"subtitle-loc-key,omitempty"`
+	SubtitleLocArgs []string `json:"subtitle-loc-args,omitempty"`
+	SummaryArg      string   `json:"summary-arg,omitempty"`
+	SummaryArgCount int      `json:"summary-arg-count,omitempty"`
+	LaunchImage     string   `json:"launch-image,omitempty"`
+}
+
+// UnmarshalJSON accepts both the string and object forms of the alert.
+func (a *IOSAlert) UnmarshalJSON(data []byte) error {
+	var body string
+	if err := json.Unmarshal(data, &body); err == nil {
+		*a = IOSAlert{Body: body}
+		return nil
+	}
+	type plain IOSAlert
+	return json.Unmarshal(data, (*plain)(a))
+}
+
+// IOSBadge is the value of the app icon badge: a number, an increment or decrement such as "+1", or "auto".
+// Use BadgeValue, BadgeIncrement or BadgeAuto to create one.
+type IOSBadge string
+
+// BadgeAuto increments the badge by one, with the SDK keeping count of it.
+const BadgeAuto IOSBadge = "auto"
+
+// BadgeValue sets the badge to n; 0 clears it. A negative n is sent as a decrement, the same as BadgeIncrement(n).
+func BadgeValue(n int) IOSBadge {
+	return IOSBadge(strconv.Itoa(n))
+}
+
+// BadgeIncrement adds n to the badge, or subtracts it if n is negative.
+func BadgeIncrement(n int) IOSBadge {
+	if n < 0 {
+		return IOSBadge(strconv.Itoa(n))
+	}
+	return IOSBadge("+" + strconv.Itoa(n))
+}
+
+// valid reports whether the badge is a number, an increment or decrement, or "auto".
+func (b IOSBadge) valid() bool {
+	if b == BadgeAuto {
+		return true
+	}
+	_, err := strconv.Atoi(string(b))
+	return err == nil
+}
+
+// MarshalJSON encodes badge values as numbers, and increments and "auto" as strings.
+func (b IOSBadge) MarshalJSON() ([]byte, error) {
+	if !strings.HasPrefix(string(b), "+") && !strings.HasPrefix(string(b), "-") {
+		if n, err := strconv.Atoi(string(b)); err == nil {
+			return json.Marshal(n)
+		}
+	}
+	return json.Marshal(string(b))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *IOSBadge) UnmarshalJSON(data []byte) error {
+	var n int
+	if err := json.Unmarshal(data, &n); err == nil {
+		*b = BadgeValue(n)
+		return nil
+	}
+	return json.Unmarshal(data, (*string)(b))
+}
+
+// IOSMediaAttachment is an image, video or sound shown in a rich notification. It needs MutableContent and a
+// notification service extension in the app.
+// https://docs.airship.com/api/ua/#schemas-mediaattachmentobject
+type IOSMediaAttachment struct {
+	URL     string           `json:"url"`
+	Options *IOSMediaOptions `json:"options,omitempty"`
+	Content *IOSMediaContent `json:"content,omitempty"` // Replaces the alert when the notification is expanded
+}
+
+// IOSMediaOptions say how a media attachment is displayed.
+type IOSMediaOptions struct {
+	Crop   *IOSMediaCrop `json:"crop,omitempty"`
+	Time   int           `json:"time,omitempty"` // Second of a video or animated image used as the thumbnail
+	Hidden bool          `json:"hidden,omitempty"`
+}
+
+// IOSMediaCrop is the thumbnail's crop rectangle, as fractions of the image between 0 and 1.
+type IOSMediaCrop struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// IOSMediaContent is the text shown with an expanded media attachment.
+type IOSMediaContent struct {
+	Body     string `json:"body,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Subtitle string `json:"subtitle,omitempty"`
+}
+
+// IOSLiveActivity updates or ends a Live Activity started by the app, as IOSOverrideWithTemplate.LiveActivity.
+// https://docs.airship.com/api/ua/#schemas-iosliveactivityobject
+type IOSLiveActivity struct {
+	Event          string                 `json:"event"` // LiveActivityEventUpdate or LiveActivityEventEnd
+	Name           string                 `json:"name"`  // Name the app registered the Live Activity with
+	ContentState   map[string]interface{} `json:"content_state,omitempty"`
+	Alert          *IOSAlert              `json:"alert,omitempty"`
+	Priority       int                    `json:"priority,omitempty"`        // IOSPriorityHigh or IOSPriorityNormal
+	RelevanceScore float64                `json:"relevance_score,omitempty"` // Any number, ranks the app's Live Activities
+	StaleDate      *time.Time             `json:"-"`                         // When the content is considered out of date
+	DismissalDate  *time.Time             `json:"-"`                         // When an ended Live Activity is removed from the lock screen
+	Timestamp      *time.Time             `json:"-"`                         // When the content changed, older updates are discarded
+}
+
+// Values of IOSLiveActivity.Event.
+const (
+	LiveActivityEventUpdate = "update"
+	LiveActivityEventEnd    = "end"
+)
+
+// MarshalJSON encodes the dates as Unix times, as APNs expects.
+func (l IOSLiveActivity) MarshalJSON() ([]byte, error) {
+	type plain IOSLiveActivity
+	return json.Marshal(struct {
+		plain
+		StaleDate     *int64 `json:"stale_date,omitempty"`
+		DismissalDate *int64 `json:"dismissal_date,omitempty"`
+		Timestamp     *int64 `json:"timestamp,omitempty"`
+	}{plain: plain(l), StaleDate: unixTime(l.StaleDate), DismissalDate: unixTime(l.DismissalDate), Timestamp: unixTime(l.Timestamp)})
+}
+
+func unixTime(t *time.Time) *int64 {
+	if t == nil {
+		return nil
+	}
+	seconds := t.Unix()
+	return &seconds
+}
+
+func (v *fieldValidator) iosOverride(path string, ios *IOSOverrideWithTemplate) {
+	v.templateRef(path+".template", ios.Template)
+	v.actions(path+".actions", ios.Actions)
+	v.iosPriority(path+".priority", ios.Priority)
+	v.relevanceScore(path+".relevance_score", ios.RelevanceScore)
+	if ios.Badge != "" && !ios.Badge.valid() {
+		v.fail(path+".badge", fmt.Sprintf(`must be a number, an increment such as "+1" or "auto", not %q`, ios.Badge))
+	}
+	switch ios.InterruptionLevel {
+	case "", InterruptionLevelPassive, InterruptionLevelActive, InterruptionLevelTimeSensitive, InterruptionLevelCritical:
+	default:
+		v.fail(path+".interruption_level", fmt.Sprintf("unknown interruption level %q", ios.InterruptionLevel))
+	}
+	if ios.MediaAttachment != nil {
+		v.required(path+".media_attachment.url", ios.MediaAttachment.URL != "")
+	}
+	if ios.LiveActivity != nil {
+		v.iosLiveActivity(path+".live_activity", ios.LiveActivity)
+	}
+}
+
+func (v *fieldValidator) iosLiveActivity(path string, l *IOSLiveActivity) {
+	v.required(path+".name", l.Name != "")
+	if l.Event != LiveActivityEventUpdate && l.Event != LiveActivityEventEnd {
+		v.fail(path+".event", fmt.Sprintf("unknown event %q", l.Event))
+	}
+	v.iosPriority(path+".priority", l.Priority)
+}
+
+func (v *fieldValidator) iosPriority(path string, priority int) {
+	if priority != 0 && priority != IOSPriorityNormal && priority != IOSPriorityHigh {
+		v.fail(path, fmt.Sprintf("must be %d or %d", IOSPriorityNormal, IOSPriorityHigh))
+	}
+}
+
+func (v *fieldValidator) relevanceScore(path string, score float64) {
+	if score < 0 || score > 1 {
+		v.fail(path, "must be between 0 and 1")
+	}
+}
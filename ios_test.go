@@ -0,0 +1,121 @@
+package airship
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIOSOverride_MarshalJSON(t *testing.T) {
+	ios := IOSOverrideWithTemplate{
+		Alert:             &IOSAlert{Title: "Sale", Body: "50% off", LocKey: "SALE"},
+		Subtitle:          "Today only",
+		Badge:             BadgeIncrement(1),
+		MutableContent:    true,
+		ContentAvailable:  true,
+		MediaAttachment:   &IOSMediaAttachment{URL: "https://example.com/a.jpg", Options: &IOSMediaOptions{Crop: &IOSMediaCrop{Width: 1, Height: 0.5}}},
+		InterruptionLevel: InterruptionLevelTimeSensitive,
+		RelevanceScore:    0.8,
+		ThreadID:          "sales",
+		TargetContentID:   "sale-window",
+		Expiry:            ExpireAfter(time.Hour),
+		Priority:          IOSPriorityHigh,
+	}
+	actual, err := json.Marshal(ios)
+	require.Nil(t, err)
+	assert.JSONEq(t, `{
+		"alert": {"title": "Sale", "body": "50% off", "loc-key": "SALE"},
+		"subtitle": "Today only",
+		"badge": "+1",
+		"mutable_content": true,
+		"content_available": true,
+		"media_attachment": {"url": "https://example.com/a.jpg", "options": {"crop": {"x": 0, "y": 0, "width": 1, "height": 0.5}}},
+		"interruption_level": "time-sensitive",
+		"relevance_score": 0.8,
+		"thread_id": "sales",
+		"target_content_id": "sale-window",
+		"expiry": 3600,
+		"priority": 10
+	}`, string(actual))
+}
+
+func TestIOSOverride_UnmarshalJSON(t *testing.T) {
+	var ios IOSOverrideWithTemplate
+	require.Nil(t, json.Unmarshal([]byte(`{"alert": "Hello", "badge": 3, "expiry": "2021-04-02T16:00:00"}`), &ios))
+	assert.Equal(t, IOSOverrideWithTemplate{
+		Alert:  &IOSAlert{Body: "Hello"},
+		Badge:  BadgeValue(3),
+		Expiry: ExpireAt(time.Date(2021, 4, 2, 16, 0, 0, 0, time.UTC)),
+	}, ios)
+}
+
+func TestIOSBadge_MarshalJSON(t *testing.T) {
+	testCases := []struct {
+		input    IOSBadge
+		expected string
+	}{
+		{input: BadgeValue(5), expected: `5`},
+		{input: BadgeValue(0), expected: `0`},
+		{input: BadgeIncrement(2), expected: `"+2"`},
+		{input: BadgeIncrement(-1), expected: `"-1"`},
+		{input: BadgeAuto, expected: `"auto"`},
+	}
+	for _, tt := range testCases {
+		t.Run(string(tt.input), func(t *testing.T) {
+			actual, err := json.Marshal(tt.input)
+			require.Nil(t, err)
+			assert.Equal(t, tt.expected, string(actual))
+
+			var decoded IOSBadge
+			require.Nil(t, json.Unmarshal(actual, &decoded))
+			assert.Equal(t, tt.input, decoded)
+			assert.True(t, decoded.valid())
+		})
+	}
+}
+
+func TestIOSLiveActivity_MarshalJSON(t *testing.T) {
+	dismissal := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	notification := NotificationObject{IOS: &IOSOverrideWithTemplate{LiveActivity: &IOSLiveActivity{
+		Event:          LiveActivityEventEnd,
+		Name:           "match-42",
+		ContentState:   map[string]interface{}{"home": 2, "away": 1},
+		Alert:          &IOSAlert{Title: "Full time", Body: "2 - 1"},
+		RelevanceScore: 75,
+		DismissalDate:  &dismissal,
+	}}}
+	actual, err := json.Marshal(notification)
+	require.Nil(t, err)
+	assert.JSONEq(t, `{"ios": {"live_activity": {
+		"event": "end",
+		"name": "match-42",
+		"content_state": {"home": 2, "away": 1},
+		"alert": {"title": "Full time", "body": "2 - 1"},
+		"relevance_score": 75,
+		"dismissal_date": 1682942400
+	}}}`, string(actual))
+	assert.Nil(t, MakeSendPushPayload(templateIDA, []string{channelA}, nil, func(n *NotificationObject) {
+		n.IOS = notification.IOS
+	}).Validate())
+}
+
+func TestIOSOverride_Validate(t *testing.T) {
+	payload := MakeSendPushPayload(templateIDA, []string{channelA}, nil)
+	payload.Notification.IOS.Priority = 7
+	payload.Notification.IOS.RelevanceScore = 2
+	payload.Notification.IOS.Badge = "lots"
+	payload.Notification.IOS.InterruptionLevel = "loud"
+	payload.Notification.IOS.MediaAttachment = &IOSMediaAttachment{}
+	payload.Notification.IOS.LiveActivity = &IOSLiveActivity{Event: "start"}
+	assert.EqualError(t, payload.Validate(), "airship: invalid payload: "+
+		"must be 5 or 10 on notification.ios.priority; "+
+		"must be between 0 and 1 on notification.ios.relevance_score; "+
+		"must be a number, an increment such as \"+1\" or \"auto\", not \"lots\" on notification.ios.badge; "+
+		"unknown interruption level \"loud\" on notification.ios.interruption_level; "+
+		"missing required value on notification.ios.media_attachment.url; "+
+		"missing required value on notification.ios.live_activity.name; "+
+		"unknown event \"start\" on notification.ios.live_activity.event")
+}
@@ -2,13 +2,31 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // Client is an autogenerated mock type for the Client type
 type Client struct {
 	mock.Mock
 }
 
+// Do provides a mock function with given fields: ctx, method, endpoint, body, out
+func (_m *Client) Do(ctx context.Context, method string, endpoint string, body interface{}, out interface{}) error {
+	ret := _m.Called(ctx, method, endpoint, body, out)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, interface{}, interface{}) error); ok {
+		r0 = rf(ctx, method, endpoint, body, out)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InvokeEndpoint provides a mock function with given fields: method, endpoint, body
 func (_m *Client) InvokeEndpoint(method string, endpoint string, body interface{}) error {
 	ret := _m.Called(method, endpoint, body)
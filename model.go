@@ -1,14 +1,89 @@
 package airship
 
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/sean-rn/go-airship/audience"
+)
+
+// timeFormat is the format of most times sent to Airship, which have no time zone and are in UTC.
+const timeFormat = "2006-01-02T15:04:05"
+
+// formatTime formats t in UTC for Airship.
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeFormat)
+}
+
+// formatOptionalTime formats t with formatTime, or returns "" if t is nil.
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatTime(*t)
+}
+
+// Timestamp is a time returned by Airship. Most timestamps in API responses have no time zone and are in UTC,
+// so Timestamp accepts both "2006-01-02T15:04:05" and RFC 3339 formats.
+type Timestamp struct {
+	time.Time
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), `"`)
+	if value == "null" || value == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		if parsed, err = time.Parse("2006-01-02T15:04:05", value); err != nil {
+			return err
+		}
+	}
+	t.Time = parsed
+	return nil
+}
+
+// The validate struct tags below only document the rules checked by the Validate methods in validate.go; nothing
+// reads them.
+
 // PushTemplatePayload https://docs.airship.com/api/ua/#schemas-pushtemplatepayload
 type PushTemplatePayload struct {
-	Audience    AudienceSelector `json:"audience" validate:"required"`
-	DeviceTypes []string         `json:"device_types" validate:"required"` // "ios" and/or "android"
-	MergeData   MergeData        `json:"merge_data" validate:"required"`
+	Audience    Audience     `json:"audience" validate:"required"`
+	DeviceTypes []DeviceType `json:"device_types" validate:"required"`
+	MergeData   MergeData    `json:"merge_data" validate:"required"`
+}
+
+// UnmarshalJSON decodes Audience as an audience.Selector, since the kind of selector isn't known while decoding.
+func (p *PushTemplatePayload) UnmarshalJSON(data []byte) error {
+	type plain PushTemplatePayload
+	var decoded struct {
+		plain
+		Audience *audience.Selector `json:"audience"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*p = PushTemplatePayload(decoded.plain)
+	if decoded.Audience != nil {
+		p.Audience = *decoded.Audience
+	}
+	return nil
+}
+
+// Audience selects the devices a push is sent to. It is implemented by AudienceSelector, and by audience.Selector
+// for the other atomic selectors and compound selectors.
+type Audience interface {
+	// ValidateAudience reports an error if the selector is empty or selects by an empty ID.
+	ValidateAudience() error
 }
 
 // AudienceSelector https://docs.airship.com/api/ua/#schemas-audienceselector
 // Atomic Selector variant: https://docs.airship.com/api/ua/#schemas-atomicselector
+// See package audience for the other atomic selectors and compound (AND/OR/NOT) selectors.
 type AudienceSelector struct {
 	Channels   []string `json:"channel,omitempty"`
 	NamedUsers []string `json:"named_user,omitempty"`
@@ -22,14 +97,31 @@ type MergeData struct {
 
 // PushObject https://docs.airship.com/api/ua/#schemas-pushobject
 type PushObject struct {
-	Audience         AudienceSelector   `json:"audience" validate:"required"`
-	DeviceTypes      interface{}        `json:"device_types" validate:"required"` // "all" or slice of "ios", "android", etc
+	Audience         Audience           `json:"audience" validate:"required"`
+	DeviceTypes      interface{}        `json:"device_types" validate:"required"` // "all" or []DeviceType
 	GlobalAttributes map[string]string  `json:"global_attributes,omitempty"`      // will be added to the global attributes rendering namespace for this push.
 	Notification     NotificationObject `json:"notification"`                     // Probably yes required unless either message or in_app is present.
 	// feed_references TODO - Probably don't need this
 	// Message uaMessageCenterWithTemplate `json:"message"` // Probably not?  Either "Message Center Object" or "Message Center with Template"
 }
 
+// UnmarshalJSON decodes Audience as an audience.Selector, since the kind of selector isn't known while decoding.
+func (p *PushObject) UnmarshalJSON(data []byte) error {
+	type plain PushObject
+	var decoded struct {
+		plain
+		Audience *audience.Selector `json:"audience"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*p = PushObject(decoded.plain)
+	if decoded.Audience != nil {
+		p.Audience = *decoded.Audience
+	}
+	return nil
+}
+
 // NotificationObject https://docs.airship.com/api/ua/#schemas-notificationobject
 type NotificationObject struct {
 	Alert   string                       `json:"alert,omitempty"`
@@ -37,6 +129,12 @@ type NotificationObject struct {
 	Android *AndroidOverrideWithTemplate `json:"android,omitempty"`
 	IOS     *IOSOverrideWithTemplate     `json:"ios,omitempty"`
 	Sms     *SMSOverrideWithTemplate     `json:"sms,omitempty"`
+	Amazon  *AmazonOverrideWithTemplate  `json:"amazon,omitempty"`
+	Web     *WebOverrideWithTemplate     `json:"web,omitempty"`
+	WNS     *WNSOverride                 `json:"wns,omitempty"`
+	Email   *EmailOverrideWithTemplate   `json:"email,omitempty"`
+	// Open holds the overrides of open channel platforms by platform name, sent as "open::<platform>".
+	Open map[string]*OpenOverride `json:"-"`
 }
 
 // SMSOverrideWithTemplate specifies an SMS message template to send.
@@ -46,37 +144,15 @@ type SMSOverrideWithTemplate struct {
 	ShortenLinks bool         `json:"shorten_links,omitempty"`
 }
 
-// AndroidOverrideWithTemplate https://docs.airship.com/api/ua/#schemas-androidoverridewithtemplate
-type AndroidOverrideWithTemplate struct {
-	Template    *TemplateRef      `json:"template,omitempty"`
-	Actions     *Actions          `json:"actions,omitempty"`
-	Extra       map[string]string `json:"extra,omitempty"`
-	Sound       string            `json:"sound,omitempty"`
-	CollapseKey string            `json:"collapse_key,omitempty"`
-	Category    string            `json:"category,omitempty"`
-	Title       string            `json:"title,omitempty"`
-}
-
-// IOSOverrideWithTemplate https://docs.airship.com/api/ua/#schemas-iosoverridewithtemplate
-type IOSOverrideWithTemplate struct {
-	Template   *TemplateRef      `json:"template,omitempty"`
-	Actions    *Actions          `json:"actions,omitempty"`
-	Extra      map[string]string `json:"extra,omitempty"`
-	Sound      string            `json:"sound,omitempty"`
-	Badge      int32             `json:"badge,omitempty"`
-	CollapseID string            `json:"collapse_id,omitempty"`
-	Category   string            `json:"category,omitempty"`
-	Title      string            `json:"title,omitempty"`
-}
-
 // TemplateRef just holds a template ID under a key.
 // One and only one of TemplateID and Fields may be populated
 type TemplateRef struct {
-	TemplateID string          `json:"template_id,omitempty" validate:"excluded_with=Fields"`
-	Fields     *TemplateFields `json:"fields,omitempty" validate:"excluded_with=TemplateID"`
+	TemplateID string           `json:"template_id,omitempty" validate:"excluded_with=Fields"`
+	Fields     TemplateFieldSet `json:"fields,omitempty" validate:"excluded_with=TemplateID"`
 }
 
 // TemplateFields allows specifying the template directly in the API call. Items in the field object are personalizable with handlebars.
+// See IOSTemplateFields, AndroidTemplateFields, SMSTemplateFields and EmailTemplateFields for the fields specific to each platform.
 type TemplateFields struct {
 	Alert     string `json:"alert,omitempty"`
 	Icon      string `json:"icon,omitempty"`
@@ -106,3 +182,43 @@ type uaOpenAction struct {
 	Content     string `json:"content"`                  // Used by URL and Deep Link
 	FallbackURL string `json:"fallback_url,omitempty"`   // Used by Deep Link
 }
+
+// Expiry says how long delivery of a notification is attempted, either for a duration after it is sent or
+// until a time. Use ExpireAfter or ExpireAt to create one.
+type Expiry struct {
+	After time.Duration // Rounded down to seconds
+	At    time.Time
+}
+
+// ExpireAfter creates an Expiry a duration after the notification is sent; 0 means it is delivered now or never.
+func ExpireAfter(d time.Duration) *Expiry {
+	return &Expiry{After: d}
+}
+
+// ExpireAt creates an Expiry at a time.
+func ExpireAt(t time.Time) *Expiry {
+	return &Expiry{At: t}
+}
+
+// MarshalJSON encodes the expiry as a number of seconds, or a UTC time.
+func (e Expiry) MarshalJSON() ([]byte, error) {
+	if !e.At.IsZero() {
+		return json.Marshal(formatTime(e.At))
+	}
+	return json.Marshal(int64(e.After / time.Second))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *Expiry) UnmarshalJSON(data []byte) error {
+	var seconds int64
+	if err := json.Unmarshal(data, &seconds); err == nil {
+		*e = Expiry{After: time.Duration(seconds) * time.Second}
+		return nil
+	}
+	var at Timestamp
+	if err := json.Unmarshal(data, &at); err != nil {
+		return err
+	}
+	*e = Expiry{At: at.Time}
+	return nil
+}
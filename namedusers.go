@@ -0,0 +1,120 @@
+package airship
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// NamedUser is a user ID from your own system, associated with any number of channels.
+// https://docs.airship.com/api/ua/#schemas-nameduser
+type NamedUser struct {
+	NamedUserID  string                 `json:"named_user_id"`
+	Tags         map[string][]string    `json:"tags,omitempty"`       // Tags by tag group
+	Attributes   map[string]interface{} `json:"attributes,omitempty"` // Custom attributes by key
+	Created      Timestamp              `json:"created"`
+	LastModified Timestamp              `json:"last_modified"`
+	Channels     []Channel              `json:"channels,omitempty"`
+}
+
+// namedUserResponse is the body returned by the named user lookup.
+type namedUserResponse struct {
+	OK        bool      `json:"ok"`
+	NamedUser NamedUser `json:"named_user"`
+}
+
+// namedUserAssociation is the body of the associate and disassociate requests.
+type namedUserAssociation struct {
+	ChannelID   string `json:"channel_id"`
+	NamedUserID string `json:"named_user_id"`
+}
+
+// namedUserSelector selects named users by ID in tag and uninstall requests.
+type namedUserSelector struct {
+	NamedUserIDs []string `json:"named_user_id"`
+}
+
+// NamedUserService invokes the Airship named users endpoints through a Client.
+type NamedUserService struct {
+	client Client
+}
+
+// NewNamedUserService creates a NamedUserService that sends its requests using client.
+func NewNamedUserService(client Client) *NamedUserService {
+	return &NamedUserService{client: client}
+}
+
+// Associate associates a channel with a named user, creating the named user if it doesn't exist yet.
+// https://docs.airship.com/api/ua/#operation-api-named_users-associate-post
+func (s *NamedUserService) Associate(ctx context.Context, namedUserID, channelID string) error {
+	body := namedUserAssociation{ChannelID: channelID, NamedUserID: namedUserID}
+	return s.client.Do(ctx, http.MethodPost, EndpointNamedUsers+"/associate", &body, nil)
+}
+
+// Disassociate removes the association between a channel and a named user.
+// https://docs.airship.com/api/ua/#operation-api-named_users-disassociate-post
+func (s *NamedUserService) Disassociate(ctx context.Context, namedUserID, channelID string) error {
+	body := namedUserAssociation{ChannelID: channelID, NamedUserID: namedUserID}
+	return s.client.Do(ctx, http.MethodPost, EndpointNamedUsers+"/disassociate", &body, nil)
+}
+
+// Get looks up a named user by ID.
+// https://docs.airship.com/api/ua/#operation-api-named_users-get
+func (s *NamedUserService) Get(ctx context.Context, namedUserID string) (*NamedUser, error) {
+	var resp namedUserResponse
+	endpoint := EndpointNamedUsers + "?" + url.Values{"id": {namedUserID}}.Encode()
+	if err := s.client.Do(ctx, http.MethodGet, endpoint, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.NamedUser, nil
+}
+
+// List returns a Pager over every named user in the project.
+// https://docs.airship.com/api/ua/#operation-api-named_users-get
+func (s *NamedUserService) List() *Pager[NamedUser] {
+	return newPager[NamedUser](s.client, EndpointNamedUsers, "named_users")
+}
+
+// AddTags adds tags in a tag group to a named user. Use UpdateTags to change several named users or tag groups
+// at once, or to read the warnings returned.
+// https://docs.airship.com/api/ua/#operation-api-named_users-tags-post
+func (s *NamedUserService) AddTags(ctx context.Context, namedUserID, group string, tags ...string) error {
+	mutation := TagMutation{}
+	_, err := s.UpdateTags(ctx, []string{namedUserID}, *mutation.AddTags(group, tags...))
+	return err
+}
+
+// RemoveTags removes tags in a tag group from a named user.
+// https://docs.airship.com/api/ua/#operation-api-named_users-tags-post
+func (s *NamedUserService) RemoveTags(ctx context.Context, namedUserID, group string, tags ...string) error {
+	mutation := TagMutation{}
+	_, err := s.UpdateTags(ctx, []string{namedUserID}, *mutation.RemoveTags(group, tags...))
+	return err
+}
+
+// SetTags replaces all of a named user's tags in a tag group. Passing no tags clears the group.
+// https://docs.airship.com/api/ua/#operation-api-named_users-tags-post
+func (s *NamedUserService) SetTags(ctx context.Context, namedUserID, group string, tags ...string) error {
+	mutation := TagMutation{}
+	_, err := s.UpdateTags(ctx, []string{namedUserID}, *mutation.SetTags(group, tags...))
+	return err
+}
+
+// SetAttribute sets a custom attribute on a named user. See MakeSetAttribute for the supported value types.
+// https://docs.airship.com/api/ua/#operation-api-named_users-named_user_id-attributes-post
+func (s *NamedUserService) SetAttribute(ctx context.Context, namedUserID, key string, value interface{}) error {
+	return s.UpdateAttributes(ctx, namedUserID, MakeSetAttribute(key, value))
+}
+
+// RemoveAttribute removes a custom attribute from a named user.
+// https://docs.airship.com/api/ua/#operation-api-named_users-named_user_id-attributes-post
+func (s *NamedUserService) RemoveAttribute(ctx context.Context, namedUserID, key string) error {
+	return s.UpdateAttributes(ctx, namedUserID, MakeRemoveAttribute(key))
+}
+
+// Uninstall disassociates and uninstalls every channel associated with the named users, then deletes them.
+// https://docs.airship.com/api/ua/#operation-api-named_users-uninstall-post
+func (s *NamedUserService) Uninstall(ctx context.Context, namedUserIDs ...string) error {
+	body := namedUserSelector{NamedUserIDs: namedUserIDs}
+	return s.client.Do(ctx, http.MethodPost, EndpointNamedUsers+"/uninstall", &body, nil)
+}
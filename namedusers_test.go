@@ -0,0 +1,134 @@
+package airship
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sean-rn/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newNamedUserTestService returns a NamedUserService whose requests must match method, url and expectedBody.
+func newNamedUserTestService(t *testing.T, method, url, expectedBody string) *NamedUserService {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, method, req.Method)
+		assert.Equal(t, url, req.URL.String())
+		assertBodyJSONEqual(t, expectedBody, req.Body)
+		rw.Write([]byte(`{"ok": true}`))
+	})
+	return NewNamedUserService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+}
+
+func TestNamedUserService_Mutations(t *testing.T) {
+	ctx := context.Background()
+	testCases := []struct {
+		name         string
+		url          string
+		expectedBody string
+		invoke       func(s *NamedUserService) error
+	}{
+		{
+			name:         "associate",
+			url:          "https://go.urbanairship.com/api/named_users/associate",
+			expectedBody: `{"channel_id": "channel-a", "named_user_id": "user-1"}`,
+			invoke:       func(s *NamedUserService) error { return s.Associate(ctx, "user-1", channelA) },
+		},
+		{
+			name:         "disassociate",
+			url:          "https://go.urbanairship.com/api/named_users/disassociate",
+			expectedBody: `{"channel_id": "channel-a", "named_user_id": "user-1"}`,
+			invoke:       func(s *NamedUserService) error { return s.Disassociate(ctx, "user-1", channelA) },
+		},
+		{
+			name:         "add tags",
+			url:          "https://go.urbanairship.com/api/named_users/tags",
+			expectedBody: `{"audience": {"named_user_id": ["user-1"]}, "add": {"crm": ["vip", "beta"]}}`,
+			invoke:       func(s *NamedUserService) error { return s.AddTags(ctx, "user-1", "crm", "vip", "beta") },
+		},
+		{
+			name:         "remove tags",
+			url:          "https://go.urbanairship.com/api/named_users/tags",
+			expectedBody: `{"audience": {"named_user_id": ["user-1"]}, "remove": {"crm": ["vip"]}}`,
+			invoke:       func(s *NamedUserService) error { return s.RemoveTags(ctx, "user-1", "crm", "vip") },
+		},
+		{
+			name:         "clear tags",
+			url:          "https://go.urbanairship.com/api/named_users/tags",
+			expectedBody: `{"audience": {"named_user_id": ["user-1"]}, "set": {"crm": []}}`,
+			invoke:       func(s *NamedUserService) error { return s.SetTags(ctx, "user-1", "crm") },
+		},
+		{
+			name:         "set attribute",
+			url:          "https://go.urbanairship.com/api/named_users/user%201/attributes",
+			expectedBody: `{"attributes": [{"action": "set", "key": "first_name", "value": "Testy"}]}`,
+			invoke:       func(s *NamedUserService) error { return s.SetAttribute(ctx, "user 1", "first_name", "Testy") },
+		},
+		{
+			name:         "remove attribute",
+			url:          "https://go.urbanairship.com/api/named_users/user-1/attributes",
+			expectedBody: `{"attributes": [{"action": "remove", "key": "first_name"}]}`,
+			invoke:       func(s *NamedUserService) error { return s.RemoveAttribute(ctx, "user-1", "first_name") },
+		},
+		{
+			name:         "uninstall",
+			url:          "https://go.urbanairship.com/api/named_users/uninstall",
+			expectedBody: `{"named_user_id": ["user-1", "user-2"]}`,
+			invoke:       func(s *NamedUserService) error { return s.Uninstall(ctx, "user-1", "user-2") },
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newNamedUserTestService(t, "POST", tt.url, tt.expectedBody)
+			assert.Nil(t, tt.invoke(service))
+		})
+	}
+}
+
+func TestNamedUserService_Get(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "GET", req.Method)
+		assert.Equal(t, "https://go.urbanairship.com/api/named_users?id=user-1", req.URL.String())
+		rw.Write([]byte(`{
+			"ok": true,
+			"named_user": {
+				"named_user_id": "user-1",
+				"tags": { "crm": ["vip"] },
+				"attributes": { "first_name": "Testy" },
+				"created": "2021-03-27T20:07:43Z",
+				"last_modified": "2021-03-28T20:07:43",
+				"channels": [{ "channel_id": "channel-a", "device_type": "ios", "opt_in": true }]
+			}
+		}`))
+	})
+	service := NewNamedUserService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	namedUser, err := service.Get(context.Background(), "user-1")
+	require.Nil(t, err)
+	assert.Equal(t, &NamedUser{
+		NamedUserID:  "user-1",
+		Tags:         map[string][]string{"crm": {"vip"}},
+		Attributes:   map[string]interface{}{"first_name": "Testy"},
+		Created:      Timestamp{time.Date(2021, 3, 27, 20, 7, 43, 0, time.UTC)},
+		LastModified: Timestamp{time.Date(2021, 3, 28, 20, 7, 43, 0, time.UTC)},
+		Channels:     []Channel{{ChannelID: channelA, DeviceType: "ios", OptIn: true}},
+	}, namedUser)
+}
+
+func TestNamedUserService_List(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "https://go.urbanairship.com/api/named_users", req.URL.String())
+		rw.Write([]byte(`{ "ok": true, "named_users": [{ "named_user_id": "user-1" }, { "named_user_id": "user-2" }] }`))
+	})
+	service := NewNamedUserService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	var ids []string
+	pager := service.List()
+	for pager.Next(context.Background()) {
+		ids = append(ids, pager.Item().NamedUserID)
+	}
+	require.Nil(t, pager.Err())
+	assert.Equal(t, []string{"user-1", "user-2"}, ids)
+}
@@ -0,0 +1,168 @@
+package airship
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// OAuthTokenURL is Airship's OAuth 2.0 token endpoint for projects in the US data center.
+	// https://docs.airship.com/api/ua/#security-oauth2
+	OAuthTokenURL = "https://oauth2.asnapius.com/token"
+	// OAuthTokenURLEU is Airship's OAuth 2.0 token endpoint for projects in the EU data center.
+	OAuthTokenURLEU = "https://oauth2.asnapieu.com/token"
+)
+
+// tokenRefreshMargin is how long before expiry a cached access token is replaced,
+// so that a token never expires while a request using it is in flight.
+const tokenRefreshMargin = time.Minute
+
+// WithOAuth configures the Airship Client to use OAuth 2.0 access tokens obtained with the client credentials grant
+// for the project with the given app key. Tokens are requested for the given scopes (e.g. "psh", "chn"), cached,
+// and refreshed shortly before they expire.
+// If Airship rejects a token with 401 Unauthorized, a new token is fetched and the request is sent once more.
+// https://docs.airship.com/api/ua/#security-oauth2
+func WithOAuth(appKey, clientID, clientSecret string, scopes ...string) ClientOption {
+	return WithAuthenticator(OAuthAuthenticator(appKey, clientID, clientSecret, "", scopes...))
+}
+
+// WithOAuthTokenURL overrides the OAuth 2.0 token endpoint used by WithOAuth or an OAuthAuthenticator.
+// By default the endpoint of the data center selected with WithRegion is used. It has no effect with other
+// kinds of authentication.
+func WithOAuthTokenURL(tokenURL string) ClientOption {
+	return func(c *uaHTTPClient) {
+		c.oauthTokenURL = tokenURL
+	}
+}
+
+// OAuthAuthenticator returns an Authenticator that uses OAuth 2.0 access tokens, as described for WithOAuth.
+// If tokenURL is empty, the token endpoint of the data center the client is configured for is used.
+// Each Airship Client the Authenticator is passed to sends token requests with its own http.Client and caches its
+// own tokens. If the Authenticator is wrapped in another one, such as an AuthenticatorFunc, the Client can't
+// configure it, so tokens are requested with http.DefaultClient from tokenURL, or OAuthTokenURL if it is empty.
+func OAuthAuthenticator(appKey, clientID, clientSecret, tokenURL string, scopes ...string) Authenticator {
+	return &oauthTokenSource{
+		tokenURL:     tokenURL,
+		appKey:       appKey,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		scopes:       scopes,
+	}
+}
+
+// oauthTokenSource fetches and caches access tokens. It is safe for concurrent use.
+type oauthTokenSource struct {
+	httpClient   *http.Client
+	tokenURL     string
+	appKey       string
+	clientID     string
+	clientSecret string
+	scopes       []string
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// withClient returns a copy of s, without any cached token, that requests tokens from tokenURL using httpClient.
+func (s *oauthTokenSource) withClient(httpClient *http.Client, tokenURL string) *oauthTokenSource {
+	return &oauthTokenSource{
+		httpClient:   httpClient,
+		tokenURL:     tokenURL,
+		appKey:       s.appKey,
+		clientID:     s.clientID,
+		clientSecret: s.clientSecret,
+		scopes:       s.scopes,
+	}
+}
+
+// oauthTokenResponse is the body returned by the token endpoint.
+type oauthTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"` // Lifetime of the token in seconds
+	Scope       string `json:"scope"`
+}
+
+// Token returns a valid access token, requesting a new one if the cached token is missing or about to expire.
+func (s *oauthTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" && time.Now().Before(s.expiry.Add(-tokenRefreshMargin)) {
+		return s.token, nil
+	}
+	resp, err := s.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	s.token = resp.AccessToken
+	s.expiry = time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second)
+	return s.token, nil
+}
+
+// Apply implements Authenticator by adding a Bearer access token to req.
+func (s *oauthTokenSource) Apply(ctx context.Context, req *http.Request) error {
+	token, err := s.Token(ctx)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// invalidate discards the token used by req from the cache, unless another caller has already replaced it.
+func (s *oauthTokenSource) invalidate(req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if "Bearer "+s.token == req.Header.Get("Authorization") {
+		s.token = ""
+	}
+}
+
+// fetch requests a new access token from the token endpoint.
+func (s *oauthTokenSource) fetch(ctx context.Context) (*oauthTokenResponse, error) {
+	form := url.Values{"grant_type": {"client_credentials"}, "sub": {"app:" + s.appKey}}
+	if len(s.scopes) > 0 {
+		form.Set("scope", strings.Join(s.scopes, " "))
+	}
+	tokenURL := s.tokenURL
+	if tokenURL == "" {
+		tokenURL = OAuthTokenURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(s.clientID, s.clientSecret)
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Add("Accept", "application/json")
+
+	httpClient := s.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("airship: requesting oauth token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("airship: requesting oauth token: %w", newAPIError(resp.StatusCode, respBody))
+	}
+	var token oauthTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return nil, fmt.Errorf("airship: decoding oauth token: %w", err)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("airship: oauth token response has no access_token")
+	}
+	return &token, nil
+}
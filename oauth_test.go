@@ -0,0 +1,151 @@
+package airship
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sean-rn/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newOAuthTestServer serves a token endpoint at /token, issuing tokens "token-1", "token-2", etc,
+// and an API at /api/push which accepts only the tokens in validTokens.
+func newOAuthTestServer(t *testing.T, expiresIn int, validTokens map[string]bool) (*httptest.Server, *int32) {
+	var issued int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "POST", req.Method)
+		clientID, secret, ok := req.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "client-id", clientID)
+		assert.Equal(t, "client-secret", secret)
+		require.Nil(t, req.ParseForm())
+		assert.Equal(t, "client_credentials", req.PostForm.Get("grant_type"))
+		assert.Equal(t, "app:app-key", req.PostForm.Get("sub"))
+		assert.Equal(t, "psh chn", req.PostForm.Get("scope"))
+
+		n := atomic.AddInt32(&issued, 1)
+		fmt.Fprintf(rw, `{"access_token": "token-%d", "token_type": "Bearer", "expires_in": %d, "scope": "psh chn"}`, n, expiresIn)
+	})
+	mux.HandleFunc("/api/push", func(rw http.ResponseWriter, req *http.Request) {
+		auth := req.Header.Get("Authorization")
+		if !validTokens[auth] {
+			rw.WriteHeader(http.StatusUnauthorized)
+			rw.Write([]byte(`{"ok": false, "error": "Unauthorized", "error_code": 40101}`))
+			return
+		}
+		rw.Write([]byte(`{"ok": true}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, &issued
+}
+
+func TestOAuth_TokenIsCached(t *testing.T) {
+	server, issued := newOAuthTestServer(t, 3600, map[string]bool{"Bearer token-1": true})
+
+	testConnection := New(WithBaseURL(server.URL), WithOAuth("app-key", "client-id", "client-secret", "psh", "chn"), WithOAuthTokenURL(server.URL+"/token"))
+
+	for i := 0; i < 3; i++ {
+		err := testConnection.Do(context.Background(), http.MethodPost, "/api/push", map[string]string{}, nil)
+		require.Nil(t, err)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(issued))
+}
+
+// Tokens that are within the refresh margin of expiring are replaced before use.
+func TestOAuth_TokenRefreshedBeforeExpiry(t *testing.T) {
+	server, issued := newOAuthTestServer(t, 30, map[string]bool{"Bearer token-1": true, "Bearer token-2": true})
+
+	testConnection := New(WithBaseURL(server.URL), WithOAuthTokenURL(server.URL+"/token"), WithOAuth("app-key", "client-id", "client-secret", "psh", "chn"))
+
+	for i := 0; i < 2; i++ {
+		err := testConnection.Do(context.Background(), http.MethodPost, "/api/push", map[string]string{}, nil)
+		require.Nil(t, err)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(issued))
+}
+
+func TestOAuth_RetriesOnceOnUnauthorized(t *testing.T) {
+	// The first token was revoked server side.
+	server, issued := newOAuthTestServer(t, 3600, map[string]bool{"Bearer token-2": true})
+
+	testConnection := New(WithBaseURL(server.URL), WithOAuth("app-key", "client-id", "client-secret", "psh", "chn"), WithOAuthTokenURL(server.URL+"/token"))
+
+	err := testConnection.Do(context.Background(), http.MethodPost, "/api/push", map[string]string{}, nil)
+	require.Nil(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(issued))
+}
+
+func TestOAuth_UnauthorizedAfterRetry(t *testing.T) {
+	server, issued := newOAuthTestServer(t, 3600, map[string]bool{})
+
+	testConnection := New(WithBaseURL(server.URL), WithOAuth("app-key", "client-id", "client-secret", "psh", "chn"), WithOAuthTokenURL(server.URL+"/token"))
+
+	err := testConnection.Do(context.Background(), http.MethodPost, "/api/push", map[string]string{}, nil)
+	assert.True(t, IsUnauthorized(err))
+	assert.Equal(t, int32(2), atomic.LoadInt32(issued))
+}
+
+func TestOAuth_TokenEndpointError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusUnauthorized)
+		rw.Write([]byte(`{"error": "invalid_client", "error_description": "Unknown client"}`))
+	}))
+	defer server.Close()
+
+	testConnection := New(WithBaseURL(server.URL), WithOAuth("app-key", "client-id", "wrong-secret"), WithOAuthTokenURL(server.URL+"/token"))
+
+	err := testConnection.Do(context.Background(), http.MethodPost, "/api/push", map[string]string{}, nil)
+	assert.True(t, IsUnauthorized(err))
+}
+
+func TestOAuth_DefaultTokenURL(t *testing.T) {
+	us := New(WithOAuth("app-key", "client-id", "client-secret")).(*uaHTTPClient)
+	assert.Equal(t, OAuthTokenURL, us.auth.(*oauthTokenSource).tokenURL)
+	eu := New(WithRegion(RegionEU), WithAuthenticator(OAuthAuthenticator("app-key", "client-id", "client-secret", ""))).(*uaHTTPClient)
+	assert.Equal(t, OAuthTokenURLEU, eu.auth.(*oauthTokenSource).tokenURL)
+}
+
+// WithOAuthTokenURL leaves other kinds of authentication alone.
+func TestWithOAuthTokenURL_OtherAuth(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "https://go.urbanairship.com/api/push", req.URL.String())
+		appKey, secret, ok := req.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "k", appKey)
+		assert.Equal(t, "s", secret)
+		rw.Write([]byte(`{"ok": true}`))
+	})
+	testConnection := New(WithHTTPClient(client), WithBasicAuth("k", "s"), WithOAuthTokenURL("https://example.com/token"))
+	assert.Nil(t, testConnection.Do(context.Background(), http.MethodPost, "/api/push", map[string]string{}, nil))
+}
+
+// Clients sharing an OAuthAuthenticator don't change each other's token URL.
+func TestOAuth_SharedAuthenticator(t *testing.T) {
+	auth := OAuthAuthenticator("app-key", "client-id", "client-secret", "")
+	us := New(WithAuthenticator(auth)).(*uaHTTPClient)
+	eu := New(WithRegion(RegionEU), WithAuthenticator(auth)).(*uaHTTPClient)
+	assert.Equal(t, OAuthTokenURL, us.auth.(*oauthTokenSource).tokenURL)
+	assert.Equal(t, OAuthTokenURLEU, eu.auth.(*oauthTokenSource).tokenURL)
+	assert.Equal(t, "", auth.(*oauthTokenSource).tokenURL)
+}
+
+// A wrapped OAuthAuthenticator requests tokens with http.DefaultClient.
+func TestOAuth_WrappedAuthenticator(t *testing.T) {
+	server, issued := newOAuthTestServer(t, 3600, map[string]bool{"Bearer token-1": true})
+
+	oauth := OAuthAuthenticator("app-key", "client-id", "client-secret", server.URL+"/token", "psh", "chn")
+	testConnection := New(WithBaseURL(server.URL), WithAuthenticator(AuthenticatorFunc(func(ctx context.Context, req *http.Request) error {
+		return oauth.Apply(ctx, req)
+	})))
+
+	err := testConnection.Do(context.Background(), http.MethodPost, "/api/push", map[string]string{}, nil)
+	require.Nil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(issued))
+}
@@ -0,0 +1,131 @@
+package airship
+
+import "fmt"
+
+// AmazonOverrideWithTemplate https://docs.airship.com/api/ua/#schemas-amazonoverridewithtemplate
+type AmazonOverrideWithTemplate struct {
+	Template            *TemplateRef      `json:"template,omitempty"`
+	Alert               string            `json:"alert,omitempty"`
+	Actions             *Actions          `json:"actions,omitempty"`
+	Extra               map[string]string `json:"extra,omitempty"`
+	Title               string            `json:"title,omitempty"`
+	Summary             string            `json:"summary,omitempty"`
+	Icon                string            `json:"icon,omitempty"`
+	IconColor           string            `json:"icon_color,omitempty"`
+	Sound               string            `json:"sound,omitempty"`
+	ConsolidationKey    string            `json:"consolidation_key,omitempty"` // Notifications with the same key replace each other
+	ExpiresAfter        *Expiry           `json:"expires_after,omitempty"`
+	NotificationChannel string            `json:"notification_channel,omitempty"`
+	NotificationTag     string            `json:"notification_tag,omitempty"`
+	Style               *AndroidStyle     `json:"style,omitempty"`
+}
+
+// WebOverrideWithTemplate https://docs.airship.com/api/ua/#schemas-weboverridewithtemplate
+type WebOverrideWithTemplate struct {
+	Template           *TemplateRef      `json:"template,omitempty"`
+	Alert              string            `json:"alert,omitempty"`
+	Actions            *Actions          `json:"actions,omitempty"`
+	Extra              map[string]string `json:"extra,omitempty"`
+	Title              string            `json:"title,omitempty"`
+	Icon               *WebImage         `json:"icon,omitempty"`
+	Image              *WebImage         `json:"image,omitempty"`
+	RequireInteraction bool              `json:"require_interaction,omitempty"` // Stays on screen until dismissed
+	TimeToLive         *Expiry           `json:"time_to_live,omitempty"`
+}
+
+// WebImage is an image of a web notification.
+type WebImage struct {
+	URL string `json:"url"`
+}
+
+// WNSOverride is the override of Windows notifications, which are toasts, tiles or badges.
+// https://docs.airship.com/api/ua/#schemas-wnsoverrideobject
+type WNSOverride struct {
+	Alert string    `json:"alert,omitempty"`
+	Toast *WNSToast `json:"toast,omitempty"`
+	Tile  *WNSTile  `json:"tile,omitempty"`
+	Badge *WNSBadge `json:"badge,omitempty"`
+}
+
+// WNSToast is a Windows toast notification.
+type WNSToast struct {
+	Binding  *WNSBinding `json:"binding,omitempty"`
+	Duration string      `json:"duration,omitempty"` // "short" or "long"
+	Audio    *WNSAudio   `json:"audio,omitempty"`
+}
+
+// WNSTile updates the app's live tile.
+type WNSTile struct {
+	Binding *WNSBinding `json:"binding,omitempty"`
+}
+
+// WNSBinding fills in a Windows toast or tile template.
+type WNSBinding struct {
+	Template string   `json:"template"` // e.g. "ToastText01"
+	Text     []string `json:"text,omitempty"`
+	Image    []string `json:"image,omitempty"`
+}
+
+// WNSAudio is the sound of a toast.
+type WNSAudio struct {
+	Sound string `json:"sound"`
+	Loop  bool   `json:"loop,omitempty"`
+}
+
+// WNSBadge sets the app's badge to a number or a glyph.
+type WNSBadge struct {
+	Value *int   `json:"value,omitempty"`
+	Glyph string `json:"glyph,omitempty"` // e.g. "alert" or "newMessage"
+}
+
+// EmailOverrideWithTemplate is the content of an email, either inline or from a template.
+// https://docs.airship.com/api/ua/#schemas-emailoverrideobject
+type EmailOverrideWithTemplate struct {
+	Template         *TemplateRef `json:"template,omitempty"` // Use EmailTemplateFields for inline fields
+	Subject          string       `json:"subject,omitempty"`
+	HTMLBody         string       `json:"html_body,omitempty"`
+	PlaintextBody    string       `json:"plaintext_body,omitempty"`
+	MessageType      string       `json:"message_type"` // EmailMessageTypeCommercial or EmailMessageTypeTransactional
+	SenderName       string       `json:"sender_name"`
+	SenderAddress    string       `json:"sender_address"` // Must be in a domain configured for the project
+	ReplyTo          string       `json:"reply_to,omitempty"`
+	BypassOptInLevel bool         `json:"bypass_opt_in_level,omitempty"`
+	ClickTracking    *bool        `json:"click_tracking,omitempty"`
+	OpenTracking     *bool        `json:"open_tracking,omitempty"`
+}
+
+// Values of EmailOverrideWithTemplate.MessageType.
+const (
+	EmailMessageTypeCommercial    = "commercial"    // Only sent to addresses opted in to commercial email
+	EmailMessageTypeTransactional = "transactional" // Sent unless the address opted out of transactional email
+)
+
+// OpenOverride is the override of an open channel platform, in NotificationObject.Open.
+// https://docs.airship.com/api/ua/#schemas-openchanneloverrideobject
+type OpenOverride struct {
+	Template        *TemplateRef         `json:"template,omitempty"`
+	Alert           string               `json:"alert,omitempty"`
+	Title           string               `json:"title,omitempty"`
+	Summary         string               `json:"summary,omitempty"`
+	Extra           map[string]string    `json:"extra,omitempty"`
+	MediaAttachment *OpenMediaAttachment `json:"media_attachment,omitempty"`
+}
+
+// OpenMediaAttachment is media sent with an open channel notification.
+type OpenMediaAttachment struct {
+	Type string `json:"type"` // "image", "video" or "audio"
+	URL  string `json:"url"`
+}
+
+func (v *fieldValidator) emailOverride(path string, email *EmailOverrideWithTemplate) {
+	v.templateRef(path+".template", email.Template)
+	switch email.MessageType {
+	case EmailMessageTypeCommercial, EmailMessageTypeTransactional:
+	case "":
+		v.required(path+".message_type", false)
+	default:
+		v.fail(path+".message_type", fmt.Sprintf("unknown message type %q", email.MessageType))
+	}
+	v.required(path+".sender_name", email.SenderName != "")
+	v.required(path+".sender_address", email.SenderAddress != "")
+}
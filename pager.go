@@ -0,0 +1,77 @@
+package airship
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Pager steps through the items of a paginated Airship list, following the next_page links in each response.
+// Pages are only fetched as the iteration reaches them, so lists of any size can be streamed.
+// For example:
+//    pager := channels.List()
+//    for pager.Next(ctx) {
+//        fmt.Println(pager.Item().ChannelID)
+//    }
+//    if err := pager.Err(); err != nil {
+//        ...
+//    }
+type Pager[T any] struct {
+	client   Client
+	itemsKey string // Property of the response holding the page's items, e.g. "channels"
+	next     string // Endpoint or next_page URL of the next page, empty once the last page has been fetched
+	page     []T
+	current  T
+	err      error
+}
+
+// newPager creates a Pager that starts at endpoint and reads each page's items from the itemsKey property.
+func newPager[T any](client Client, endpoint, itemsKey string) *Pager[T] {
+	return &Pager[T]{client: client, itemsKey: itemsKey, next: endpoint}
+}
+
+// Next advances to the next item, fetching the next page if needed. It returns false when there are no more
+// items or an error occurred, which is then returned by Err.
+func (p *Pager[T]) Next(ctx context.Context) bool {
+	for len(p.page) == 0 {
+		if p.err != nil || p.next == "" {
+			return false
+		}
+		if p.err = p.fetch(ctx); p.err != nil {
+			return false
+		}
+	}
+	p.current, p.page = p.page[0], p.page[1:]
+	return true
+}
+
+// Item returns the item Next advanced to.
+func (p *Pager[T]) Item() T {
+	return p.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// fetch requests the next page.
+func (p *Pager[T]) fetch(ctx context.Context) error {
+	var resp map[string]json.RawMessage
+	if err := p.client.Do(ctx, http.MethodGet, p.next, nil, &resp); err != nil {
+		return err
+	}
+	p.next = ""
+	if nextPage, ok := resp["next_page"]; ok {
+		if err := json.Unmarshal(nextPage, &p.next); err != nil {
+			return fmt.Errorf("airship: decoding next_page: %w", err)
+		}
+	}
+	if items, ok := resp[p.itemsKey]; ok {
+		if err := json.Unmarshal(items, &p.page); err != nil {
+			return fmt.Errorf("airship: decoding %s: %w", p.itemsKey, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package airship
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sean-rn/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type pagerTestItem struct {
+	ID string `json:"id"`
+}
+
+func TestPager_FollowsNextPage(t *testing.T) {
+	pages := map[string]string{
+		"/api/things":         `{"ok": true, "next_page": "https://go.urbanairship.com/api/things?start=b", "things": [{"id": "a"}]}`,
+		"/api/things?start=b": `{"ok": true, "next_page": "https://go.urbanairship.com/api/things?start=d", "things": [{"id": "b"}, {"id": "c"}]}`,
+		"/api/things?start=d": `{"ok": true, "things": []}`,
+	}
+	var requested []string
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "Bearer test-ua-token", req.Header.Get("Authorization"))
+		requested = append(requested, req.URL.RequestURI())
+		rw.Write([]byte(pages[req.URL.RequestURI()]))
+	})
+
+	pager := newPager[pagerTestItem](New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)), "/api/things", "things")
+
+	var ids []string
+	for pager.Next(context.Background()) {
+		ids = append(ids, pager.Item().ID)
+	}
+	require.Nil(t, pager.Err())
+	assert.Equal(t, []string{"a", "b", "c"}, ids)
+	assert.Equal(t, []string{"/api/things", "/api/things?start=b", "/api/things?start=d"}, requested)
+	assert.False(t, pager.Next(context.Background())) // Stays finished
+}
+
+func TestPager_StopsOnError(t *testing.T) {
+	calls := 0
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+		if calls > 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		rw.Write([]byte(`{"ok": true, "next_page": "https://go.urbanairship.com/api/things?start=b", "things": [{"id": "a"}]}`))
+	})
+
+	pager := newPager[pagerTestItem](New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)), "/api/things", "things")
+
+	assert.True(t, pager.Next(context.Background()))
+	assert.False(t, pager.Next(context.Background()))
+	assert.Error(t, pager.Err())
+	assert.False(t, pager.Next(context.Background()))
+	assert.Equal(t, 2, calls)
+}
+
+// A next_page link to another host must not receive the client's credentials.
+func TestPager_RefusesForeignNextPage(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "go.urbanairship.com", req.URL.Host)
+		rw.Write([]byte(`{"ok": true, "next_page": "https://evil.example.com/api/things?start=b", "things": [{"id": "a"}]}`))
+	})
+
+	pager := newPager[pagerTestItem](New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)), "/api/things", "things")
+
+	assert.True(t, pager.Next(context.Background()))
+	assert.False(t, pager.Next(context.Background()))
+	assert.EqualError(t, pager.Err(), "airship: refusing to send request to https://evil.example.com/api/things?start=b, which is not under https://go.urbanairship.com")
+}
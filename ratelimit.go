@@ -0,0 +1,69 @@
+package airship
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithRateLimit throttles the Airship Client to an average of <rps> requests per second, allowing bursts of up
+// to <burst> requests. The limit is shared by every goroutine using the client, and applies to retries too.
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return func(c *uaHTTPClient) {
+		if rps <= 0 {
+			c.limiter = nil
+			return
+		}
+		c.limiter = newRateLimiter(rps, burst)
+	}
+}
+
+// rateLimiter is a token bucket that is safe for concurrent use.
+// Callers reserve a token up front, so waiting goroutines are released in order at the configured rate.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // Tokens added per second
+	burst  float64 // Capacity of the bucket
+	tokens float64 // May go negative, which represents tokens reserved by waiting callers
+	last   time.Time
+}
+
+func newRateLimiter(rps float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until the caller may send a request, or returns the context's error if ctx is done first.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	l.tokens-- // Reserve our token
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+	if err := sleepContext(ctx, delay); err != nil {
+		l.mu.Lock()
+		l.tokens++ // Give back the reservation we won't use
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
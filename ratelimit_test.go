@@ -0,0 +1,63 @@
+package airship
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sean-rn/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit_SharedAcrossGoroutines(t *testing.T) {
+	var mu sync.Mutex
+	var sentAt []time.Time
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		sentAt = append(sentAt, time.Now())
+		mu.Unlock()
+	})
+
+	// 100 rps with a burst of 2: the first two go immediately, then one every 10ms.
+	testConnection := New(WithHTTPClient(client), WithBearerAuth(TestBearerToken), WithRateLimit(100, 2))
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, testConnection.InvokeEndpoint(http.MethodPost, "/api/push", map[string]string{}))
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, sentAt, 6)
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(35*time.Millisecond))
+}
+
+func TestRateLimit_ContextCanceledWhileWaiting(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {})
+
+	// One request per minute: the second call would have to wait far longer than its deadline.
+	testConnection := New(WithHTTPClient(client), WithBearerAuth(TestBearerToken), WithRateLimit(1.0/60, 1))
+
+	assert.Nil(t, testConnection.Do(context.Background(), http.MethodPost, "/api/push", map[string]string{}, nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := testConnection.Do(ctx, http.MethodPost, "/api/push", map[string]string{}, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRateLimiter_ReturnsCanceledReservation(t *testing.T) {
+	limiter := newRateLimiter(1, 1)
+	assert.Nil(t, limiter.wait(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, limiter.wait(ctx), context.Canceled)
+	assert.InDelta(t, 0, limiter.tokens, 0.01) // Still only the first token consumed
+}
@@ -0,0 +1,213 @@
+package airship
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// RenderTemplate renders text the way Airship personalizes it with handlebars, using the same substitutions
+// map passed to MakeSendPushPayload. It supports {{VAR}} (HTML escaped), {{{VAR}}} and {{&VAR}} (not escaped),
+// {{#if VAR}}, {{#unless VAR}} and {{else}} blocks, and {{! comments}}. Missing variables render as "", and
+// other helpers, partials and data variables such as {{@index}} are rejected.
+// This is meant for previewing and testing templates; Airship's own rendering is authoritative.
+func RenderTemplate(text string, substitutions map[string]string) (string, error) {
+	p := templateParser{text: text}
+	nodes, end, err := p.parse()
+	if err != nil {
+		return "", err
+	}
+	if end != "" {
+		return "", fmt.Errorf("airship: template: unexpected {{%s}}", end)
+	}
+	var b strings.Builder
+	renderNodes(&b, nodes, substitutions)
+	return b.String(), nil
+}
+
+// RenderTemplateFields renders every field of fields with RenderTemplate, returning a copy of the same type.
+func RenderTemplateFields(fields TemplateFieldSet, substitutions map[string]string) (TemplateFieldSet, error) {
+	if raw, ok := fields.(RawTemplateFields); ok {
+		rendered := make(RawTemplateFields, len(raw))
+		for name, value := range raw {
+			if text, ok := value.(string); ok {
+				var err error
+				if value, err = RenderTemplate(text, substitutions); err != nil {
+					return nil, fmt.Errorf("%w in field %s", err, name)
+				}
+			}
+			rendered[name] = value
+		}
+		return rendered, nil
+	}
+	if !hasTemplateFields(fields) {
+		return fields, nil
+	}
+	src := reflect.ValueOf(fields).Elem()
+	dst := reflect.New(src.Type())
+	dst.Elem().Set(src)
+	for i := 0; i < src.NumField(); i++ {
+		field := dst.Elem().Field(i)
+		if field.Kind() != reflect.String {
+			continue
+		}
+		rendered, err := RenderTemplate(field.String(), substitutions)
+		if err != nil {
+			return nil, fmt.Errorf("%w in field %s", err, src.Type().Field(i).Name)
+		}
+		field.SetString(rendered)
+	}
+	return dst.Interface().(TemplateFieldSet), nil
+}
+
+// templateNode is a parsed piece of a template.
+type templateNode struct {
+	text     string         // Literal text, when name is empty
+	name     string         // Variable or block condition
+	raw      bool           // Variable isn't HTML escaped
+	block    string         // "if" or "unless" for blocks
+	children []templateNode // Block content rendered when the condition holds
+	inverse  []templateNode // Block content after {{else}}
+}
+
+type templateParser struct {
+	text string
+	pos  int
+}
+
+// parse reads nodes until the end of the text or a {{else}} or {{/block}} tag, which is returned.
+func (p *templateParser) parse() ([]templateNode, string, error) {
+	var nodes []templateNode
+	for p.pos < len(p.text) {
+		start := strings.Index(p.text[p.pos:], "{{")
+		if start < 0 {
+			nodes = append(nodes, templateNode{text: p.text[p.pos:]})
+			p.pos = len(p.text)
+			break
+		}
+		start += p.pos
+		if start > 0 && p.text[start-1] == '\\' { // \{{ is a literal {{
+			nodes = append(nodes, templateNode{text: p.text[p.pos:start-1] + "{{"})
+			p.pos = start + 2
+			continue
+		}
+		if start > p.pos {
+			nodes = append(nodes, templateNode{text: p.text[p.pos:start]})
+		}
+		tag, err := p.tag(start)
+		if err != nil {
+			return nil, "", err
+		}
+
+		switch {
+		case strings.HasPrefix(tag, "!"):
+			// Comment
+		case tag == "else" || strings.HasPrefix(tag, "/"):
+			return nodes, tag, nil
+		case strings.HasPrefix(tag, "#"):
+			node, err := p.block(tag)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, node)
+		case strings.HasPrefix(tag, "{"):
+			name, err := variableName(strings.TrimSuffix(tag[1:], "}"))
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, templateNode{name: name, raw: true})
+		case strings.HasPrefix(tag, "&"):
+			name, err := variableName(tag[1:])
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, templateNode{name: name, raw: true})
+		default:
+			name, err := variableName(tag)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, templateNode{name: name})
+		}
+	}
+	return nodes, "", nil
+}
+
+// tag reads the tag starting at start and returns its content without the outer braces.
+func (p *templateParser) tag(start int) (string, error) {
+	closing := "}}"
+	switch {
+	case strings.HasPrefix(p.text[start:], "{{{"):
+		closing = "}}}"
+	case strings.HasPrefix(p.text[start:], "{{!--"):
+		closing = "--}}"
+	}
+	end := strings.Index(p.text[start+2:], closing)
+	if end < 0 {
+		return "", fmt.Errorf("airship: template: unclosed tag at offset %d", start)
+	}
+	end += start + 2
+	p.pos = end + len(closing)
+	if closing == "}}}" {
+		return p.text[start+2:end] + "}", nil
+	}
+	return strings.TrimSpace(p.text[start+2 : end]), nil
+}
+
+// block parses the content of a {{#if}} or {{#unless}} block up to its closing tag.
+func (p *templateParser) block(tag string) (templateNode, error) {
+	fields := strings.Fields(tag[1:])
+	if len(fields) != 2 || (fields[0] != "if" && fields[0] != "unless") {
+		return templateNode{}, fmt.Errorf("airship: template: unsupported block {{%s}}", tag)
+	}
+	node := templateNode{block: fields[0], name: fields[1]}
+	var end string
+	var err error
+	if node.children, end, err = p.parse(); err != nil {
+		return templateNode{}, err
+	}
+	if end == "else" {
+		if node.inverse, end, err = p.parse(); err != nil {
+			return templateNode{}, err
+		}
+	}
+	if end != "/"+node.block {
+		return templateNode{}, fmt.Errorf("airship: template: {{#%s %s}} isn't closed by {{/%s}}", node.block, node.name, node.block)
+	}
+	return node, nil
+}
+
+// handlebarsSigils start tags that aren't variables, such as partials {{>name}} and data variables {{@index}}.
+const handlebarsSigils = ">@^#/~!&{}*$=(."
+
+func variableName(tag string) (string, error) {
+	name := strings.TrimSpace(tag)
+	if name == "" || strings.ContainsAny(name, " \t\n") || strings.ContainsAny(name[:1], handlebarsSigils) {
+		return "", fmt.Errorf("airship: template: unsupported helper {{%s}}", name)
+	}
+	return name, nil
+}
+
+var handlebarsEscaper = strings.NewReplacer(
+	"&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&#x27;", "`", "&#x60;", "=", "&#x3D;",
+)
+
+func renderNodes(b *strings.Builder, nodes []templateNode, substitutions map[string]string) {
+	for _, node := range nodes {
+		switch {
+		case node.block != "":
+			holds := substitutions[node.name] != ""
+			if holds == (node.block == "if") {
+				renderNodes(b, node.children, substitutions)
+			} else {
+				renderNodes(b, node.inverse, substitutions)
+			}
+		case node.name == "":
+			b.WriteString(node.text)
+		case node.raw:
+			b.WriteString(substitutions[node.name])
+		default:
+			handlebarsEscaper.WriteString(b, substitutions[node.name])
+		}
+	}
+}
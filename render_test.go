@@ -0,0 +1,99 @@
+package airship
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	subs := map[string]string{"FIRST_NAME": "Testy", "LINK": "<a href='x'>go</a>", "VIP": "yes"}
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+		err      string
+	}{
+		{name: "plain", input: "Hello", expected: "Hello"},
+		{name: "variable", input: "Hello {{FIRST_NAME}}!", expected: "Hello Testy!"},
+		{name: "spaces", input: "Hello {{ FIRST_NAME }}!", expected: "Hello Testy!"},
+		{name: "missing", input: "Hello {{LAST_NAME}}!", expected: "Hello !"},
+		{name: "escaped", input: "{{LINK}}", expected: "&lt;a href&#x3D;&#x27;x&#x27;&gt;go&lt;/a&gt;"},
+		{name: "triple stash", input: "{{{LINK}}}", expected: "<a href='x'>go</a>"},
+		{name: "ampersand", input: "{{& LINK}}", expected: "<a href='x'>go</a>"},
+		{name: "literal braces", input: `\{{FIRST_NAME}}`, expected: "{{FIRST_NAME}}"},
+		{name: "comments", input: "a{{! one }}b{{!-- two }} --}}c", expected: "abc"},
+		{name: "if", input: "{{#if VIP}}Dear {{FIRST_NAME}}{{/if}}", expected: "Dear Testy"},
+		{name: "if else", input: "{{#if LAST_NAME}}x{{else}}Hi {{FIRST_NAME}}{{/if}}", expected: "Hi Testy"},
+		{name: "unless", input: "{{#unless VIP}}Upgrade!{{else}}Thanks{{/unless}}", expected: "Thanks"},
+		{name: "nested", input: "{{#if VIP}}{{#unless LAST_NAME}}{{FIRST_NAME}}{{/unless}}{{/if}}", expected: "Testy"},
+		{name: "unclosed tag", input: "Hello {{FIRST_NAME", err: "airship: template: unclosed tag at offset 6"},
+		{name: "unclosed block", input: "{{#if VIP}}x", err: "airship: template: {{#if VIP}} isn't closed by {{/if}}"},
+		{name: "stray close", input: "x{{/if}}", err: "airship: template: unexpected {{/if}}"},
+		{name: "unsupported block", input: "{{#each ITEMS}}x{{/each}}", err: "airship: template: unsupported block {{#each ITEMS}}"},
+		{name: "unsupported helper", input: `{{$def FIRST_NAME "friend"}}`, err: `airship: template: unsupported helper {{$def FIRST_NAME "friend"}}`},
+		{name: "partial", input: `{{>footer}}`, err: `airship: template: unsupported helper {{>footer}}`},
+		{name: "data variable", input: `{{@index}}`, err: `airship: template: unsupported helper {{@index}}`},
+		{name: "raw partial", input: `{{{>footer}}}`, err: `airship: template: unsupported helper {{>footer}}`},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := RenderTemplate(tt.input, subs)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestRenderTemplateFields(t *testing.T) {
+	subs := map[string]string{"FIRST_NAME": "Testy"}
+
+	email := &EmailTemplateFields{Subject: "Hi {{FIRST_NAME}}", PlaintextBody: "Welcome, {{FIRST_NAME}}.", SenderName: "Acme"}
+	rendered, err := RenderTemplateFields(email, subs)
+	require.Nil(t, err)
+	assert.Equal(t, &EmailTemplateFields{Subject: "Hi Testy", PlaintextBody: "Welcome, Testy.", SenderName: "Acme"}, rendered)
+	assert.Equal(t, "Hi {{FIRST_NAME}}", email.Subject, "the input is unchanged")
+
+	rendered, err = RenderTemplateFields(RawTemplateFields{"alert": "Hi {{FIRST_NAME}}", "badge": 1.0}, subs)
+	require.Nil(t, err)
+	assert.Equal(t, RawTemplateFields{"alert": "Hi Testy", "badge": 1.0}, rendered)
+
+	_, err = RenderTemplateFields(&IOSTemplateFields{Subtitle: "{{#if X}}"}, subs)
+	assert.EqualError(t, err, "airship: template: {{#if X}} isn't closed by {{/if}} in field Subtitle")
+}
+
+func TestTemplateFields_MarshalJSON(t *testing.T) {
+	payload := MakeSendPushPayload("", []string{channelA}, nil)
+	payload.Notification.IOS.Template = &TemplateRef{Fields: &IOSTemplateFields{Title: "Hi", Subtitle: "There", Body: "Body"}}
+	payload.Notification.Android.Template = &TemplateRef{Fields: &AndroidTemplateFields{Alert: "Hi", BigPicture: "https://example.com/a.png"}}
+	payload.Notification.Sms = &SMSOverrideWithTemplate{Template: &TemplateRef{Fields: &SMSTemplateFields{Alert: "Hi"}}}
+
+	actual, err := json.Marshal(payload.Notification)
+	require.Nil(t, err)
+	assert.JSONEq(t, `{
+		"ios": {"template": {"fields": {"title": "Hi", "subtitle": "There", "body": "Body"}}},
+		"android": {"template": {"fields": {"alert": "Hi", "big_picture": "https://example.com/a.png"}}},
+		"sms": {"template": {"fields": {"alert": "Hi"}}}
+	}`, string(actual))
+
+	var decoded NotificationObject
+	require.Nil(t, json.Unmarshal(actual, &decoded))
+	assert.Equal(t, RawTemplateFields{"alert": "Hi"}, decoded.Sms.Template.Fields)
+}
+
+func TestTemplateRef_MarshalJSON_TypedNilFields(t *testing.T) {
+	var fields *TemplateFields
+	payload := MakeSendPushPayload(templateIDA, []string{channelA}, nil)
+	payload.Notification.IOS.Template.Fields = fields
+	assert.Nil(t, payload.Validate())
+
+	actual, err := json.Marshal(payload.Notification.IOS.Template)
+	require.Nil(t, err)
+	assert.JSONEq(t, `{"template_id": "template-id-a"}`, string(actual))
+}
@@ -0,0 +1,91 @@
+package airship
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how the client retries requests that failed in a way that is safe to repeat:
+// connection failures before the request was sent, 429 Too Many Requests, and 502/503/504 responses.
+type RetryPolicy struct {
+	MaxAttempts int           // Total number of attempts, including the first. Values below 2 disable retries.
+	BaseDelay   time.Duration // Delay before the first retry, doubled for each retry after that.
+	MaxDelay    time.Duration // Upper limit on the backoff delay. Zero means no limit.
+	Jitter      float64       // Fraction (0 to 1) of each backoff delay that is randomized to spread out retries.
+}
+
+// WithRetry configures the Airship Client to retry failed requests according to policy.
+// A Retry-After header sent by Airship takes precedence over the computed backoff.
+func WithRetry(policy RetryPolicy) ClientOption {
+	return func(c *uaHTTPClient) {
+		c.retry = &policy
+	}
+}
+
+// isRetryable reports whether the outcome of an attempt is safe and worthwhile to repeat.
+func isRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		// Only retry when the connection was never established, so the request can't have been processed.
+		var opErr *net.OpError
+		return errors.As(err, &opErr) && opErr.Op == "dial"
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// delay returns how long to wait before the attempt following <attempt>.
+func (p *RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return d
+		}
+	}
+	d := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay == 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		d -= time.Duration(p.Jitter * rand.Float64() * float64(d))
+	}
+	return d
+}
+
+// parseRetryAfter parses a Retry-After header, which holds either a number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		if d := time.Until(when); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// sleepContext waits for d, returning early with the context's error if ctx is done first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
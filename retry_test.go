@@ -0,0 +1,150 @@
+package airship
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sean-rn/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRetryPolicy = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+func TestRetry_RateLimitedThenSucceeds(t *testing.T) {
+	assert := assert.New(t)
+
+	attempts := 0
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		attempts++
+		assertBodyJSONEqual(t, `{ "message": "Hello World" }`, req.Body) // The body is re-sent each time
+		if attempts < 3 {
+			rw.Header().Set("Retry-After", "0")
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		rw.Write([]byte(`{"ok": true,"operation_id": "df6a6b50","push_ids": ["9d78a53b"]}`))
+	})
+
+	testConnection := New(WithHTTPClient(client), WithBearerAuth(TestBearerToken), WithRetry(testRetryPolicy))
+
+	var result PushResponse
+	err := testConnection.Do(context.Background(), http.MethodPost, "/api/push", map[string]string{"message": "Hello World"}, &result)
+	require.Nil(t, err)
+	assert.Equal(3, attempts)
+	assert.Equal("df6a6b50", result.OperationID)
+}
+
+func TestRetry_GivesUpAfterMaxAttempts(t *testing.T) {
+	attempts := 0
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		attempts++
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	testConnection := New(WithHTTPClient(client), WithBearerAuth(TestBearerToken), WithRetry(testRetryPolicy))
+
+	err := testConnection.InvokeEndpoint(http.MethodPost, "/api/push", map[string]string{})
+	assert.Equal(t, 3, attempts)
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+}
+
+func TestRetry_NotRetryable(t *testing.T) {
+	testCases := []struct {
+		name      string
+		transport httpmock.RoundTripperFunc
+	}{
+		{
+			name: "bad request",
+			transport: httpmock.HandlerTransport(func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusBadRequest)
+			}),
+		},
+		{
+			name: "internal server error",
+			transport: httpmock.HandlerTransport(func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusInternalServerError)
+			}),
+		},
+		{
+			name: "connection reset after sending",
+			transport: func(req *http.Request) (*http.Response, error) {
+				return nil, &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+			},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			client := httpmock.NewTransportClient(func(req *http.Request) (*http.Response, error) {
+				attempts++
+				return tt.transport(req)
+			})
+			testConnection := New(WithHTTPClient(client), WithBearerAuth(TestBearerToken), WithRetry(testRetryPolicy))
+
+			err := testConnection.InvokeEndpoint(http.MethodPost, "/api/push", map[string]string{})
+			assert.Error(t, err)
+			assert.Equal(t, 1, attempts)
+		})
+	}
+}
+
+func TestRetry_DialError(t *testing.T) {
+	attempts := 0
+	client := httpmock.NewTransportClient(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+		}
+		return httpmock.HandlerTransport(func(rw http.ResponseWriter, req *http.Request) {})(req)
+	})
+
+	testConnection := New(WithHTTPClient(client), WithBearerAuth(TestBearerToken), WithRetry(testRetryPolicy))
+
+	err := testConnection.InvokeEndpoint(http.MethodPost, "/api/push", map[string]string{})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, attempts)
+}
+
+// Waiting between attempts stops as soon as the context is done.
+func TestRetry_ContextCanceledWhileWaiting(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Retry-After", "3600")
+		rw.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	testConnection := New(WithHTTPClient(client), WithBearerAuth(TestBearerToken), WithRetry(testRetryPolicy))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := testConnection.Do(ctx, http.MethodPost, "/api/push", map[string]string{}, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	retryAfter := func(value string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": []string{value}}}
+	}
+
+	assert.Equal(t, 100*time.Millisecond, policy.delay(1, nil))
+	assert.Equal(t, 200*time.Millisecond, policy.delay(2, nil))
+	assert.Equal(t, 400*time.Millisecond, policy.delay(3, &http.Response{}))
+	assert.Equal(t, time.Second, policy.delay(5, nil))
+	assert.Equal(t, time.Second, policy.delay(80, nil))
+	assert.Equal(t, 30*time.Second, policy.delay(1, retryAfter("30")))
+	assert.Equal(t, 100*time.Millisecond, policy.delay(1, retryAfter("soon")))
+	assert.Equal(t, time.Duration(0), policy.delay(1, retryAfter("Wed, 21 Oct 2015 07:28:00 GMT")))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 20; i++ {
+		d := policy.delay(2, nil)
+		assert.True(t, d > 100*time.Millisecond && d <= 200*time.Millisecond, "delay %v out of range", d)
+	}
+}
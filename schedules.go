@@ -0,0 +1,172 @@
+package airship
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+)
+
+// Cadence types for recurring schedules.
+const (
+	CadenceHourly  = "hourly"
+	CadenceDaily   = "daily"
+	CadenceWeekly  = "weekly"
+	CadenceMonthly = "monthly"
+	CadenceYearly  = "yearly"
+)
+
+// Schedule is a push to send at a later time.
+// https://docs.airship.com/api/ua/#schemas-scheduleobject
+type Schedule struct {
+	URL      string       `json:"url,omitempty"` // Set by Airship on schedules it returns.
+	Name     string       `json:"name,omitempty"`
+	Schedule ScheduleSpec `json:"schedule"`
+	Push     PushObject   `json:"push"`
+	PushIDs  []string     `json:"push_ids,omitempty"` // Set by Airship on schedules it returns.
+}
+
+// ID returns the schedule's ID, taken from the URL Airship assigned it.
+func (s Schedule) ID() string {
+	if s.URL == "" {
+		return ""
+	}
+	return path.Base(s.URL)
+}
+
+// ScheduleSpec says when a scheduled push is sent. Exactly one of ScheduledTime, LocalScheduledTime and BestTime
+// may be set; Recurring is combined with ScheduledTime as the first occurrence.
+// Use ScheduleAt, ScheduleAtLocalTime, ScheduleAtBestTime or ScheduleRecurring to create one.
+// https://docs.airship.com/api/ua/#schemas-schedulespecification
+type ScheduleSpec struct {
+	ScheduledTime      string     `json:"scheduled_time,omitempty"`       // UTC time, "YYYY-MM-DDTHH:MM:SS"
+	LocalScheduledTime string     `json:"local_scheduled_time,omitempty"` // Time in each device's own time zone
+	BestTime           *BestTime  `json:"best_time,omitempty"`
+	Recurring          *Recurring `json:"recurring,omitempty"`
+}
+
+// BestTime sends the push to each user at the time of day they are most likely to engage on the given date.
+type BestTime struct {
+	SendDate string `json:"send_date"` // "YYYY-MM-DD"
+}
+
+// Recurring repeats a scheduled push at a regular cadence.
+// https://docs.airship.com/api/ua/#schemas-recurringschedule
+type Recurring struct {
+	Cadence Cadence `json:"cadence"`
+	EndTime string  `json:"end_time,omitempty"` // UTC time, "YYYY-MM-DDTHH:MM:SS"
+	Paused  bool    `json:"paused,omitempty"`
+}
+
+// Cadence is how often a recurring schedule repeats, e.g. every 2 weeks.
+type Cadence struct {
+	Type       string   `json:"type"`  // One of the Cadence* constants
+	Count      int      `json:"count"` // Number of cadence periods between pushes
+	DaysOfWeek []string `json:"days_of_week,omitempty"`
+}
+
+// ScheduleAt creates a schedule specification that sends at the instant t.
+func ScheduleAt(t time.Time) ScheduleSpec {
+	return ScheduleSpec{ScheduledTime: formatTime(t)}
+}
+
+// ScheduleAtLocalTime creates a schedule specification that sends at the wall clock time of t (ignoring its
+// location) in each device's own time zone. For example 9am becomes 9am for every recipient.
+func ScheduleAtLocalTime(t time.Time) ScheduleSpec {
+	return ScheduleSpec{LocalScheduledTime: t.Format(timeFormat)}
+}
+
+// ScheduleAtBestTime creates a schedule specification that sends on the date of day at each user's optimal time.
+func ScheduleAtBestTime(day time.Time) ScheduleSpec {
+	return ScheduleSpec{BestTime: &BestTime{SendDate: day.Format("2006-01-02")}}
+}
+
+// ScheduleRecurring creates a schedule specification that first sends at start and then repeats with cadence.
+// If end is the zero time the schedule repeats forever.
+func ScheduleRecurring(start time.Time, cadence Cadence, end time.Time) ScheduleSpec {
+	recurring := Recurring{Cadence: cadence}
+	if !end.IsZero() {
+		recurring.EndTime = formatTime(end)
+	}
+	spec := ScheduleAt(start)
+	spec.Recurring = &recurring
+	return spec
+}
+
+// ScheduleResponse is the body returned by Airship when schedules are created or updated.
+type ScheduleResponse struct {
+	OK           bool       `json:"ok"`
+	OperationID  string     `json:"operation_id"`
+	ScheduleURLs []string   `json:"schedule_urls"`
+	ScheduleIDs  []string   `json:"schedule_ids"`
+	Schedules    []Schedule `json:"schedules"`
+}
+
+// ScheduleService invokes the Airship schedules endpoints through a Client.
+type ScheduleService struct {
+	client Client
+}
+
+// NewScheduleService creates a ScheduleService that sends its requests using client.
+func NewScheduleService(client Client) *ScheduleService {
+	return &ScheduleService{client: client}
+}
+
+// Create schedules one or more pushes.
+// https://docs.airship.com/api/ua/#operation-api-schedules-post
+func (s *ScheduleService) Create(ctx context.Context, schedules ...Schedule) (*ScheduleResponse, error) {
+	var resp ScheduleResponse
+	if err := s.client.Do(ctx, http.MethodPost, EndpointSchedules, schedules, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// List returns a Pager over every scheduled push.
+// https://docs.airship.com/api/ua/#operation-api-schedules-get
+func (s *ScheduleService) List() *Pager[Schedule] {
+	return newPager[Schedule](s.client, EndpointSchedules, "schedules")
+}
+
+// Get looks up a schedule by ID.
+// https://docs.airship.com/api/ua/#operation-api-schedules-schedule_id-get
+func (s *ScheduleService) Get(ctx context.Context, scheduleID string) (*Schedule, error) {
+	var resp Schedule
+	if err := s.client.Do(ctx, http.MethodGet, schedulePath(scheduleID), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Update replaces a schedule, both its specification and its push.
+// https://docs.airship.com/api/ua/#operation-api-schedules-schedule_id-put
+func (s *ScheduleService) Update(ctx context.Context, scheduleID string, schedule Schedule) (*ScheduleResponse, error) {
+	var resp ScheduleResponse
+	if err := s.client.Do(ctx, http.MethodPut, schedulePath(scheduleID), &schedule, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Delete deletes a schedule so it is never sent.
+// https://docs.airship.com/api/ua/#operation-api-schedules-schedule_id-delete
+func (s *ScheduleService) Delete(ctx context.Context, scheduleID string) error {
+	return s.client.Do(ctx, http.MethodDelete, schedulePath(scheduleID), nil, nil)
+}
+
+// Pause stops a recurring schedule from sending until it is resumed.
+// https://docs.airship.com/api/ua/#operation-api-schedules-schedule_id-pause-post
+func (s *ScheduleService) Pause(ctx context.Context, scheduleID string) error {
+	return s.client.Do(ctx, http.MethodPost, schedulePath(scheduleID)+"/pause", nil, nil)
+}
+
+// Resume restarts a paused recurring schedule.
+// https://docs.airship.com/api/ua/#operation-api-schedules-schedule_id-resume-post
+func (s *ScheduleService) Resume(ctx context.Context, scheduleID string) error {
+	return s.client.Do(ctx, http.MethodPost, schedulePath(scheduleID)+"/resume", nil, nil)
+}
+
+func schedulePath(scheduleID string) string {
+	return EndpointSchedules + "/" + url.PathEscape(scheduleID)
+}
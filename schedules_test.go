@@ -0,0 +1,192 @@
+package airship
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sean-rn/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleSpec_MarshalJSON(t *testing.T) {
+	pacific := time.FixedZone("PDT", -7*60*60)
+	nineAM := time.Date(2021, 4, 2, 9, 0, 0, 0, pacific)
+
+	testCases := []struct {
+		name     string
+		input    ScheduleSpec
+		expected string
+	}{
+		{name: "scheduled time", input: ScheduleAt(nineAM), expected: `{"scheduled_time": "2021-04-02T16:00:00"}`},
+		{name: "local scheduled time", input: ScheduleAtLocalTime(nineAM), expected: `{"local_scheduled_time": "2021-04-02T09:00:00"}`},
+		{name: "best time", input: ScheduleAtBestTime(nineAM), expected: `{"best_time": {"send_date": "2021-04-02"}}`},
+		{
+			name:  "recurring",
+			input: ScheduleRecurring(nineAM, Cadence{Type: CadenceWeekly, Count: 2, DaysOfWeek: []string{"monday"}}, nineAM.AddDate(0, 3, 0)),
+			expected: `{
+				"scheduled_time": "2021-04-02T16:00:00",
+				"recurring": {
+					"cadence": {"type": "weekly", "count": 2, "days_of_week": ["monday"]},
+					"end_time": "2021-07-02T16:00:00"
+				}
+			}`,
+		},
+		{
+			name:     "recurring forever",
+			input:    ScheduleRecurring(nineAM, Cadence{Type: CadenceDaily, Count: 1}, time.Time{}),
+			expected: `{"scheduled_time": "2021-04-02T16:00:00", "recurring": {"cadence": {"type": "daily", "count": 1}}}`,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			bytes, err := json.Marshal(tt.input)
+			require.Nil(t, err)
+			assert.JSONEq(t, tt.expected, string(bytes))
+		})
+	}
+}
+
+func TestScheduleService_Create(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedBody := `[{
+		"name": "Reminder",
+		"schedule": { "local_scheduled_time": "2021-04-02T09:00:00" },
+		"push": {
+			"audience": { "channel": ["channel-a"] },
+			"device_types": ["ios", "android"],
+			"notification": {
+				"ios": { "template": { "template_id": "template-id-a" } },
+				"android": { "template": { "template_id": "template-id-a" } }
+			}
+		}
+	}]`
+
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal("POST", req.Method)
+		assert.Equal("https://go.urbanairship.com/api/schedules", req.URL.String())
+		assertBodyJSONEqual(t, expectedBody, req.Body)
+		rw.WriteHeader(http.StatusCreated)
+		rw.Write([]byte(`{
+			"ok": true,
+			"operation_id": "efb18e92",
+			"schedule_urls": ["https://go.urbanairship.com/api/schedules/2d69320c"],
+			"schedule_ids": ["2d69320c"],
+			"schedules": [{
+				"url": "https://go.urbanairship.com/api/schedules/2d69320c",
+				"schedule": { "local_scheduled_time": "2021-04-02T09:00:00" },
+				"name": "Reminder",
+				"push": { "audience": { "channel": ["channel-a"] }, "device_types": ["ios", "android"], "notification": {} },
+				"push_ids": ["8f18fcb5"]
+			}]
+		}`))
+	})
+	service := NewScheduleService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	resp, err := service.Create(context.Background(), Schedule{
+		Name:     "Reminder",
+		Schedule: ScheduleAtLocalTime(time.Date(2021, 4, 2, 9, 0, 0, 0, time.UTC)),
+		Push:     MakeSendPushPayload(templateIDA, []string{channelA}, nil),
+	})
+	require.Nil(t, err)
+	assert.Equal([]string{"2d69320c"}, resp.ScheduleIDs)
+	require.Len(t, resp.Schedules, 1)
+	assert.Equal("2d69320c", resp.Schedules[0].ID())
+	assert.Equal([]string{"8f18fcb5"}, resp.Schedules[0].PushIDs)
+}
+
+func TestScheduleService_List(t *testing.T) {
+	assert := assert.New(t)
+
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal("GET", req.Method)
+		assert.Equal("https://go.urbanairship.com/api/schedules", req.URL.String())
+		assert.Empty(req.Header.Get("Content-Type"))
+		rw.Write([]byte(`{
+			"ok": true,
+			"count": 1,
+			"total_count": 2,
+			"schedules": [{
+				"url": "https://go.urbanairship.com/api/schedules/2d69320c",
+				"schedule": { "scheduled_time": "2021-04-02T16:00:00" },
+				"push": { "audience": "all", "device_types": "all", "notification": { "alert": "Hello" } }
+			}]
+		}`))
+	})
+	service := NewScheduleService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	pager := service.List()
+	require.True(t, pager.Next(context.Background()))
+	assert.Equal("2d69320c", pager.Item().ID())
+	assert.Equal("Hello", pager.Item().Push.Notification.Alert)
+	assert.False(pager.Next(context.Background()))
+	assert.Nil(pager.Err())
+}
+
+func TestScheduleService_Get(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "GET", req.Method)
+		assert.Equal(t, "https://go.urbanairship.com/api/schedules/2d69320c", req.URL.String())
+		rw.Write([]byte(`{
+			"url": "https://go.urbanairship.com/api/schedules/2d69320c",
+			"name": "Reminder",
+			"schedule": { "best_time": { "send_date": "2021-04-02" } },
+			"push": { "audience": "all", "device_types": "all", "notification": { "alert": "Hello" } }
+		}`))
+	})
+	service := NewScheduleService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	schedule, err := service.Get(context.Background(), "2d69320c")
+	require.Nil(t, err)
+	assert.Equal(t, "Reminder", schedule.Name)
+	assert.Equal(t, &BestTime{SendDate: "2021-04-02"}, schedule.Schedule.BestTime)
+	// The decoded push can be sent again as it was
+	assert.Nil(t, schedule.Push.Validate())
+	pushJSON, err := json.Marshal(schedule.Push)
+	require.Nil(t, err)
+	assert.JSONEq(t, `{"audience": "all", "device_types": "all", "notification": {"alert": "Hello"}}`, string(pushJSON))
+}
+
+func TestScheduleService_Update(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "PUT", req.Method)
+		assert.Equal(t, "https://go.urbanairship.com/api/schedules/2d69320c", req.URL.String())
+		rw.Write([]byte(`{"ok": true, "operation_id": "7c56d013", "schedule_urls": ["https://go.urbanairship.com/api/schedules/2d69320c"]}`))
+	})
+	service := NewScheduleService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	resp, err := service.Update(context.Background(), "2d69320c", Schedule{
+		Schedule: ScheduleAt(time.Date(2021, 4, 2, 16, 0, 0, 0, time.UTC)),
+		Push:     MakeSendPushPayload(templateIDA, []string{channelA}, nil),
+	})
+	require.Nil(t, err)
+	assert.Equal(t, "7c56d013", resp.OperationID)
+}
+
+func TestScheduleService_NoContentOperations(t *testing.T) {
+	service := func(t *testing.T, method, url string) *ScheduleService {
+		client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, method, req.Method)
+			assert.Equal(t, url, req.URL.String())
+			rw.WriteHeader(http.StatusNoContent)
+		})
+		return NewScheduleService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+	}
+
+	t.Run("delete", func(t *testing.T) {
+		err := service(t, "DELETE", "https://go.urbanairship.com/api/schedules/2d69320c").Delete(context.Background(), "2d69320c")
+		assert.Nil(t, err)
+	})
+	t.Run("pause", func(t *testing.T) {
+		err := service(t, "POST", "https://go.urbanairship.com/api/schedules/2d69320c/pause").Pause(context.Background(), "2d69320c")
+		assert.Nil(t, err)
+	})
+	t.Run("resume", func(t *testing.T) {
+		err := service(t, "POST", "https://go.urbanairship.com/api/schedules/2d69320c/resume").Resume(context.Background(), "2d69320c")
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,132 @@
+package airship
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// SMSRegistration is the body returned by Airship when a phone number is registered.
+type SMSRegistration struct {
+	OK        bool   `json:"ok"`
+	ChannelID string `json:"channel_id,omitempty"` // Empty while Status is SMSRegistrationPending
+	Status    string `json:"status,omitempty"`
+}
+
+// SMSRegistrationPending is the SMSRegistration.Status of a number registered without an opt in date. Airship
+// texts it asking to opt in, and the channel is created once it does.
+const SMSRegistrationPending = "pending"
+
+// smsChannelRequest is the body of the SMS register and update requests.
+type smsChannelRequest struct {
+	MSISDN  string `json:"msisdn"`
+	Sender  string `json:"sender"`
+	OptedIn string `json:"opted_in,omitempty"`
+}
+
+func newSMSChannelRequest(target CreateAndSendSMSTarget) *smsChannelRequest {
+	body := &smsChannelRequest{MSISDN: target.MSISDN, Sender: target.Sender}
+	if !target.OptedIn.IsZero() {
+		body.OptedIn = formatTime(target.OptedIn)
+	}
+	return body
+}
+
+// Validate checks that the phone number and sender are set.
+func (r smsChannelRequest) Validate() error {
+	v := fieldValidator{}
+	v.required("msisdn", r.MSISDN != "")
+	v.required("sender", r.Sender != "")
+	return v.err()
+}
+
+// smsTargetRequest is the body of the SMS opt-out and uninstall requests.
+type smsTargetRequest struct {
+	MSISDN string `json:"msisdn"`
+	Sender string `json:"sender"`
+}
+
+// Validate checks that the phone number and sender are set.
+func (r smsTargetRequest) Validate() error {
+	return smsChannelRequest{MSISDN: r.MSISDN, Sender: r.Sender}.Validate()
+}
+
+// smsKeywordRequest is the body of the SMS keyword interaction request. The sender is part of the endpoint.
+type smsKeywordRequest struct {
+	Keyword string `json:"keyword"`
+	MSISDN  string `json:"msisdn"`
+	Sender  string `json:"-"`
+}
+
+// Validate checks that the keyword, phone number and sender are set.
+func (r smsKeywordRequest) Validate() error {
+	v := fieldValidator{}
+	v.required("keyword", r.Keyword != "")
+	v.required("msisdn", r.MSISDN != "")
+	v.required("sender", r.Sender != "")
+	return v.err()
+}
+
+// SMSService invokes the Airship SMS channel endpoints through a Client. Phone numbers are given as the same
+// CreateAndSendSMSTarget used to send them messages.
+type SMSService struct {
+	client Client
+}
+
+// NewSMSService creates an SMSService that sends its requests using client.
+func NewSMSService(client Client) *SMSService {
+	return &SMSService{client: client}
+}
+
+// Register registers a phone number with a sender. If target.OptedIn is zero, the registration is pending until
+// the number opts in by text.
+// https://docs.airship.com/api/ua/#operation-api-channels-sms-post
+func (s *SMSService) Register(ctx context.Context, target CreateAndSendSMSTarget) (*SMSRegistration, error) {
+	var resp SMSRegistration
+	if err := s.client.Do(ctx, http.MethodPost, EndpointSMSChannels, newSMSChannelRequest(target), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Update replaces the phone number, sender and opt in date of an SMS channel.
+// https://docs.airship.com/api/ua/#operation-api-channels-sms-channel_id-put
+func (s *SMSService) Update(ctx context.Context, channelID string, target CreateAndSendSMSTarget) error {
+	endpoint := EndpointSMSChannels + "/" + url.PathEscape(channelID)
+	return s.client.Do(ctx, http.MethodPut, endpoint, newSMSChannelRequest(target), nil)
+}
+
+// OptOut records that a phone number opted out of messages from the sender. The channel is kept, so the opt out
+// is remembered, but no more messages are sent to it.
+// https://docs.airship.com/api/ua/#operation-api-channels-sms-opt-out-post
+func (s *SMSService) OptOut(ctx context.Context, target CreateAndSendSMSTarget) error {
+	body := smsTargetRequest{MSISDN: target.MSISDN, Sender: target.Sender}
+	return s.client.Do(ctx, http.MethodPost, EndpointSMSChannels+"/opt-out", &body, nil)
+}
+
+// Uninstall removes the SMS channel of a phone number and sender, along with its opt in history.
+// https://docs.airship.com/api/ua/#operation-api-channels-sms-uninstall-post
+func (s *SMSService) Uninstall(ctx context.Context, target CreateAndSendSMSTarget) error {
+	body := smsTargetRequest{MSISDN: target.MSISDN, Sender: target.Sender}
+	return s.client.Do(ctx, http.MethodPost, EndpointSMSChannels+"/uninstall", &body, nil)
+}
+
+// Lookup looks up the SMS channel of a phone number and sender.
+// https://docs.airship.com/api/ua/#operation-api-channels-sms-msisdn-sender-get
+func (s *SMSService) Lookup(ctx context.Context, msisdn, sender string) (*Channel, error) {
+	var resp channelResponse
+	endpoint := EndpointSMSChannels + "/" + url.PathEscape(msisdn) + "/" + url.PathEscape(sender)
+	if err := s.client.Do(ctx, http.MethodGet, endpoint, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Channel, nil
+}
+
+// SendKeyword records that a phone number texted keyword to the sender, triggering the opt in, opt out or other
+// action the keyword is configured with, as if the number had sent the text itself.
+// https://docs.airship.com/api/ua/#operation-api-sms-sender-keywords-post
+func (s *SMSService) SendKeyword(ctx context.Context, target CreateAndSendSMSTarget, keyword string) error {
+	body := smsKeywordRequest{Keyword: keyword, MSISDN: target.MSISDN, Sender: target.Sender}
+	endpoint := EndpointSMS + "/" + url.PathEscape(target.Sender) + "/keywords"
+	return s.client.Do(ctx, http.MethodPost, endpoint, &body, nil)
+}
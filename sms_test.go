@@ -0,0 +1,147 @@
+package airship
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sean-rn/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var smsTarget = CreateAndSendSMSTarget{
+	MSISDN:  "15035556789",
+	Sender:  "12345",
+	OptedIn: time.Date(2021, 3, 27, 20, 7, 43, 0, time.UTC),
+}
+
+func TestSMSService_Register(t *testing.T) {
+	testCases := []struct {
+		name         string
+		target       CreateAndSendSMSTarget
+		expectedBody string
+		status       int
+		response     string
+		expected     *SMSRegistration
+	}{
+		{
+			name:         "opted in",
+			target:       smsTarget,
+			expectedBody: `{"msisdn": "15035556789", "sender": "12345", "opted_in": "2021-03-27T20:07:43"}`,
+			status:       http.StatusCreated,
+			response:     `{"ok": true, "channel_id": "sms-channel-1"}`,
+			expected:     &SMSRegistration{OK: true, ChannelID: "sms-channel-1"},
+		},
+		{
+			name:         "pending",
+			target:       CreateAndSendSMSTarget{MSISDN: "15035556789", Sender: "12345"},
+			expectedBody: `{"msisdn": "15035556789", "sender": "12345"}`,
+			status:       http.StatusAccepted,
+			response:     `{"ok": true, "status": "pending"}`,
+			expected:     &SMSRegistration{OK: true, Status: SMSRegistrationPending},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, "POST", req.Method)
+				assert.Equal(t, "https://go.urbanairship.com/api/channels/sms", req.URL.String())
+				assertBodyJSONEqual(t, tt.expectedBody, req.Body)
+				rw.WriteHeader(tt.status)
+				rw.Write([]byte(tt.response))
+			})
+			service := NewSMSService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+			resp, err := service.Register(context.Background(), tt.target)
+			require.Nil(t, err)
+			assert.Equal(t, tt.expected, resp)
+		})
+	}
+}
+
+func TestSMSService_UpdateOptOutUninstall(t *testing.T) {
+	ctx := context.Background()
+	testCases := []struct {
+		name         string
+		method       string
+		url          string
+		expectedBody string
+		invoke       func(s *SMSService) error
+	}{
+		{
+			name:         "update",
+			method:       "PUT",
+			url:          "https://go.urbanairship.com/api/channels/sms/sms-channel-1",
+			expectedBody: `{"msisdn": "15035556789", "sender": "12345", "opted_in": "2021-03-27T20:07:43"}`,
+			invoke:       func(s *SMSService) error { return s.Update(ctx, "sms-channel-1", smsTarget) },
+		},
+		{
+			name:         "opt out",
+			method:       "POST",
+			url:          "https://go.urbanairship.com/api/channels/sms/opt-out",
+			expectedBody: `{"msisdn": "15035556789", "sender": "12345"}`,
+			invoke:       func(s *SMSService) error { return s.OptOut(ctx, smsTarget) },
+		},
+		{
+			name:         "uninstall",
+			method:       "POST",
+			url:          "https://go.urbanairship.com/api/channels/sms/uninstall",
+			expectedBody: `{"msisdn": "15035556789", "sender": "12345"}`,
+			invoke:       func(s *SMSService) error { return s.Uninstall(ctx, smsTarget) },
+		},
+		{
+			name:         "keyword",
+			method:       "POST",
+			url:          "https://go.urbanairship.com/api/sms/12345/keywords",
+			expectedBody: `{"keyword": "STOP", "msisdn": "15035556789"}`,
+			invoke:       func(s *SMSService) error { return s.SendKeyword(ctx, smsTarget, "STOP") },
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, tt.method, req.Method)
+				assert.Equal(t, tt.url, req.URL.String())
+				assertBodyJSONEqual(t, tt.expectedBody, req.Body)
+				rw.Write([]byte(`{"ok": true}`))
+			})
+			service := NewSMSService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+			assert.Nil(t, tt.invoke(service))
+		})
+	}
+}
+
+func TestSMSService_Lookup(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "GET", req.Method)
+		assert.Equal(t, "https://go.urbanairship.com/api/channels/sms/15035556789/12345", req.URL.String())
+		rw.Write([]byte(`{"ok": true, "channel": {
+			"channel_id": "sms-channel-1",
+			"device_type": "sms",
+			"installed": true,
+			"opt_in": false,
+			"push_address": null
+		}}`))
+	})
+	service := NewSMSService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	channel, err := service.Lookup(context.Background(), smsTarget.MSISDN, smsTarget.Sender)
+	require.Nil(t, err)
+	assert.Equal(t, &Channel{ChannelID: "sms-channel-1", DeviceType: "sms", Installed: true}, channel)
+}
+
+func TestSMSService_Validates(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		t.Error("no request should have been sent")
+	})
+	service := NewSMSService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	err := service.OptOut(context.Background(), CreateAndSendSMSTarget{Sender: "12345"})
+	assert.EqualError(t, err, "airship: invalid payload: missing required value on msisdn")
+	assert.True(t, IsValidationError(err))
+
+	err = service.SendKeyword(context.Background(), smsTarget, "")
+	assert.EqualError(t, err, "airship: invalid payload: missing required value on keyword")
+}
@@ -0,0 +1,111 @@
+package airship
+
+import (
+	"context"
+	"net/http"
+)
+
+// TagMutation adds, removes or sets tags in any number of tag groups. Set replaces every tag in a group and
+// can't be combined with Add or Remove in the same request.
+// For example:
+//    mutation := airship.TagMutation{}
+//    mutation.AddTags("crm", "vip").RemoveTags("crm", "trial").AddTags("loyalty", "gold")
+type TagMutation struct {
+	Add    map[string][]string `json:"add,omitempty"`
+	Remove map[string][]string `json:"remove,omitempty"`
+	Set    map[string][]string `json:"set,omitempty"`
+}
+
+// AddTags adds tags in group to the mutation's Add operations.
+func (m *TagMutation) AddTags(group string, tags ...string) *TagMutation {
+	m.Add = appendTags(m.Add, group, tags)
+	return m
+}
+
+// RemoveTags adds tags in group to the mutation's Remove operations.
+func (m *TagMutation) RemoveTags(group string, tags ...string) *TagMutation {
+	m.Remove = appendTags(m.Remove, group, tags)
+	return m
+}
+
+// SetTags adds tags in group to the mutation's Set operations. Passing no tags clears the group.
+func (m *TagMutation) SetTags(group string, tags ...string) *TagMutation {
+	m.Set = appendTags(m.Set, group, tags)
+	return m
+}
+
+func appendTags(groups map[string][]string, group string, tags []string) map[string][]string {
+	if groups == nil {
+		groups = map[string][]string{}
+	}
+	if groups[group] == nil {
+		groups[group] = []string{} // Must be sent as [] rather than null
+	}
+	groups[group] = append(groups[group], tags...)
+	return groups
+}
+
+func (v *fieldValidator) tagMutation(m *TagMutation) {
+	if len(m.Add)+len(m.Remove)+len(m.Set) == 0 {
+		v.fail("", "at least one of add, remove or set is required")
+	}
+	if len(m.Set) > 0 && len(m.Add)+len(m.Remove) > 0 {
+		v.fail("set", "set can't be combined with add or remove")
+	}
+}
+
+// TagResponse is the body returned by Airship when tags are changed.
+type TagResponse struct {
+	OK       bool     `json:"ok"`
+	Warnings []string `json:"warnings,omitempty"` // e.g. channels that don't exist, which were skipped
+}
+
+// channelTagsRequest is the body of the channel tags request.
+type channelTagsRequest struct {
+	Audience ChannelAudience `json:"audience"`
+	TagMutation
+}
+
+// Validate checks that the request selects some channels and has a valid mutation.
+func (r channelTagsRequest) Validate() error {
+	v := fieldValidator{}
+	v.channelAudience("audience", &r.Audience)
+	v.tagMutation(&r.TagMutation)
+	return v.err()
+}
+
+// namedUserTagsRequest is the body of the named user tags request.
+type namedUserTagsRequest struct {
+	Audience namedUserSelector `json:"audience"`
+	TagMutation
+}
+
+// Validate checks that the request selects some named users and has a valid mutation.
+func (r namedUserTagsRequest) Validate() error {
+	v := fieldValidator{}
+	v.required("audience.named_user_id", len(r.Audience.NamedUserIDs) > 0)
+	v.tagMutation(&r.TagMutation)
+	return v.err()
+}
+
+// UpdateTags applies mutation to every channel in audience in a single request.
+// https://docs.airship.com/api/ua/#operation-api-channels-tags-post
+func (s *ChannelService) UpdateTags(ctx context.Context, audience ChannelAudience, mutation TagMutation) (*TagResponse, error) {
+	body := channelTagsRequest{Audience: audience, TagMutation: mutation}
+	var resp TagResponse
+	if err := s.client.Do(ctx, http.MethodPost, EndpointChannels+"/tags", &body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateTags applies mutation to every one of the named users in a single request.
+// https://docs.airship.com/api/ua/#operation-api-named_users-tags-post
+func (s *NamedUserService) UpdateTags(ctx context.Context, namedUserIDs []string, mutation TagMutation) (*TagResponse, error) {
+	body := namedUserTagsRequest{Audience: namedUserSelector{NamedUserIDs: namedUserIDs}, TagMutation: mutation}
+	var resp TagResponse
+	if err := s.client.Do(ctx, http.MethodPost, EndpointNamedUsers+"/tags", &body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,76 @@
+package airship
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sean-rn/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagMutation(t *testing.T) {
+	mutation := TagMutation{}
+	mutation.AddTags("crm", "vip").AddTags("crm", "beta").RemoveTags("loyalty", "silver").SetTags("empty")
+	assert.Equal(t, TagMutation{
+		Add:    map[string][]string{"crm": {"vip", "beta"}},
+		Remove: map[string][]string{"loyalty": {"silver"}},
+		Set:    map[string][]string{"empty": {}},
+	}, mutation)
+}
+
+func TestChannelService_UpdateTags(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "POST", req.Method)
+		assert.Equal(t, "https://go.urbanairship.com/api/channels/tags", req.URL.String())
+		assertBodyJSONEqual(t, `{
+			"audience": { "ios_channel": ["channel-a"], "android_channel": ["channel-b", "channel-c"] },
+			"add": { "crm": ["vip"], "loyalty": ["gold"] },
+			"remove": { "crm": ["trial"] }
+		}`, req.Body)
+		rw.Write([]byte(`{"ok": true, "warnings": ["The following channels were not found: channel-c"]}`))
+	})
+	service := NewChannelService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	mutation := TagMutation{}
+	mutation.AddTags("crm", "vip").AddTags("loyalty", "gold").RemoveTags("crm", "trial")
+	audience := ChannelAudience{IOSChannels: []string{channelA}, AndroidChannels: []string{channelB, "channel-c"}}
+	resp, err := service.UpdateTags(context.Background(), audience, mutation)
+	require.Nil(t, err)
+	assert.Equal(t, &TagResponse{OK: true, Warnings: []string{"The following channels were not found: channel-c"}}, resp)
+}
+
+func TestNamedUserService_UpdateTags(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "https://go.urbanairship.com/api/named_users/tags", req.URL.String())
+		assertBodyJSONEqual(t, `{
+			"audience": { "named_user_id": ["user-1", "user-2"] },
+			"set": { "crm": ["vip"], "loyalty": [] }
+		}`, req.Body)
+		rw.Write([]byte(`{"ok": true}`))
+	})
+	service := NewNamedUserService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	mutation := TagMutation{}
+	mutation.SetTags("crm", "vip").SetTags("loyalty")
+	resp, err := service.UpdateTags(context.Background(), []string{"user-1", "user-2"}, mutation)
+	require.Nil(t, err)
+	assert.Equal(t, &TagResponse{OK: true}, resp)
+}
+
+func TestUpdateTags_Validates(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		t.Error("no request should have been sent")
+	})
+	testConnection := New(WithHTTPClient(client), WithBearerAuth(TestBearerToken))
+
+	mutation := TagMutation{}
+	mutation.AddTags("crm", "vip").SetTags("loyalty", "gold")
+	_, err := NewChannelService(testConnection).UpdateTags(context.Background(), ChannelAudience{}, mutation)
+	assert.EqualError(t, err, "airship: invalid payload: missing required value on audience; set can't be combined with add or remove on set")
+
+	_, err = NewNamedUserService(testConnection).UpdateTags(context.Background(), []string{"user-1"}, TagMutation{})
+	assert.EqualError(t, err, "airship: invalid payload: at least one of add, remove or set is required")
+	assert.True(t, IsValidationError(err))
+}
@@ -0,0 +1,96 @@
+package airship
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// TemplateFieldSet is implemented by the inline template field types that can be used as TemplateRef.Fields:
+// *TemplateFields, *IOSTemplateFields, *AndroidTemplateFields, *SMSTemplateFields, *EmailTemplateFields and
+// RawTemplateFields.
+type TemplateFieldSet interface {
+	templateFields()
+}
+
+// IOSTemplateFields are the inline template fields of an iOS notification.
+// https://docs.airship.com/api/ua/#schemas-iosfields
+type IOSTemplateFields struct {
+	Alert    string `json:"alert,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Subtitle string `json:"subtitle,omitempty"`
+	Body     string `json:"body,omitempty"`
+}
+
+// AndroidTemplateFields are the inline template fields of an Android notification.
+// https://docs.airship.com/api/ua/#schemas-androidfields
+type AndroidTemplateFields struct {
+	Alert      string `json:"alert,omitempty"`
+	Title      string `json:"title,omitempty"`
+	Summary    string `json:"summary,omitempty"`
+	Icon       string `json:"icon,omitempty"`
+	IconColor  string `json:"icon_color,omitempty"`
+	BigPicture string `json:"big_picture,omitempty"` // URL of the image shown when the notification is expanded
+}
+
+// SMSTemplateFields are the inline template fields of an SMS message.
+// https://docs.airship.com/api/ua/#schemas-smsfields
+type SMSTemplateFields struct {
+	Alert string `json:"alert,omitempty"`
+}
+
+// EmailTemplateFields are the inline template fields of an email.
+// https://docs.airship.com/api/ua/#schemas-emailfields
+type EmailTemplateFields struct {
+	Subject       string `json:"subject,omitempty"`
+	HTMLBody      string `json:"html_body,omitempty"`
+	PlaintextBody string `json:"plaintext_body,omitempty"`
+	SenderName    string `json:"sender_name,omitempty"`
+	SenderAddress string `json:"sender_address,omitempty"`
+	ReplyTo       string `json:"reply_to,omitempty"`
+}
+
+// RawTemplateFields holds template fields by name. Fields decoded from Airship responses are RawTemplateFields,
+// since the platform they belong to isn't known while decoding.
+type RawTemplateFields map[string]interface{}
+
+func (*TemplateFields) templateFields()        {}
+func (*IOSTemplateFields) templateFields()     {}
+func (*AndroidTemplateFields) templateFields() {}
+func (*SMSTemplateFields) templateFields()     {}
+func (*EmailTemplateFields) templateFields()   {}
+func (RawTemplateFields) templateFields()      {}
+
+// MarshalJSON omits Fields when it holds a nil pointer or map, which omitempty alone would send as null.
+func (r TemplateRef) MarshalJSON() ([]byte, error) {
+	type plain TemplateRef
+	if !hasTemplateFields(r.Fields) {
+		r.Fields = nil
+	}
+	return json.Marshal(plain(r))
+}
+
+// UnmarshalJSON decodes Fields as RawTemplateFields.
+func (r *TemplateRef) UnmarshalJSON(data []byte) error {
+	var ref struct {
+		TemplateID string            `json:"template_id"`
+		Fields     RawTemplateFields `json:"fields"`
+	}
+	if err := json.Unmarshal(data, &ref); err != nil {
+		return err
+	}
+	r.TemplateID = ref.TemplateID
+	r.Fields = nil
+	if ref.Fields != nil {
+		r.Fields = ref.Fields
+	}
+	return nil
+}
+
+// hasTemplateFields reports whether fields is set, treating a nil pointer of a field type as unset.
+func hasTemplateFields(fields TemplateFieldSet) bool {
+	if fields == nil {
+		return false
+	}
+	v := reflect.ValueOf(fields)
+	return !((v.Kind() == reflect.Ptr || v.Kind() == reflect.Map) && v.IsNil())
+}
@@ -0,0 +1,214 @@
+package airship
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+// Template is a push notification whose text contains {{VARIABLE}} placeholders, filled in from the
+// substitutions of each push to the template.
+// https://docs.airship.com/api/ua/#schemas-templateobject
+type Template struct {
+	ID          string             `json:"id,omitempty"`
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Variables   []TemplateVariable `json:"variables"`
+	Push        TemplatePush       `json:"push"`
+	CreatedAt   Timestamp          `json:"created_at"`
+	ModifiedAt  Timestamp          `json:"modified_at"`
+	LastUsed    Timestamp          `json:"last_used"`
+}
+
+// TemplateVariable declares a placeholder used in a Template.
+type TemplateVariable struct {
+	Key          string `json:"key"` // Name of the placeholder, e.g. "FIRST_NAME" for {{FIRST_NAME}}
+	FriendlyName string `json:"friendly_name"`
+	DefaultValue string `json:"default_value,omitempty"` // Used when a push doesn't substitute the variable
+	Required     bool   `json:"required,omitempty"`
+}
+
+// TemplatePush is the partial push object of a Template. Its audience and device types are given by each push.
+type TemplatePush struct {
+	Notification NotificationObject `json:"notification"`
+}
+
+// Validate checks that the template is named and its variables have keys, and checks its notification the same way
+// as PushObject.Validate.
+func (t Template) Validate() error {
+	v := fieldValidator{}
+	v.required("name", t.Name != "")
+	for i, variable := range t.Variables {
+		v.required(fmt.Sprintf("variables[%d].key", i), variable.Key != "")
+	}
+	v.notification("push.notification", &t.Push.Notification)
+	return v.err()
+}
+
+// templateBody is the body of the create and update requests, which only take a template's writable fields.
+type templateBody struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Variables   []TemplateVariable `json:"variables"`
+	Push        TemplatePush       `json:"push"`
+}
+
+func newTemplateBody(t Template) *templateBody {
+	if t.Variables == nil {
+		t.Variables = []TemplateVariable{}
+	}
+	return &templateBody{Name: t.Name, Description: t.Description, Variables: t.Variables, Push: t.Push}
+}
+
+// Validate checks the template being sent.
+func (b templateBody) Validate() error {
+	return Template{Name: b.Name, Variables: b.Variables, Push: b.Push}.Validate()
+}
+
+// TemplateResponse is the body returned by Airship when a template is created, updated or deleted.
+type TemplateResponse struct {
+	OK          bool   `json:"ok"`
+	OperationID string `json:"operation_id"`
+	TemplateURL string `json:"template_url,omitempty"` // Only returned on creation
+}
+
+// TemplateID returns the ID of a created template, which is the last segment of its TemplateURL.
+func (r *TemplateResponse) TemplateID() string {
+	if r.TemplateURL == "" {
+		return ""
+	}
+	return path.Base(r.TemplateURL)
+}
+
+// templateResponse is the body returned by the template lookup.
+type templateResponse struct {
+	OK       bool     `json:"ok"`
+	Template Template `json:"template"`
+}
+
+// templatePreviewRequest is the body of the template preview request.
+type templatePreviewRequest struct {
+	Substitutions map[string]string `json:"substitutions"`
+}
+
+// templatePreviewResponse is the body returned by the template preview.
+type templatePreviewResponse struct {
+	OK      bool       `json:"ok"`
+	Preview PushObject `json:"preview"`
+}
+
+// TemplateService invokes the Airship templates endpoints through a Client.
+type TemplateService struct {
+	client Client
+}
+
+// NewTemplateService creates a TemplateService that sends its requests using client.
+func NewTemplateService(client Client) *TemplateService {
+	return &TemplateService{client: client}
+}
+
+// Create creates a template. Its ID is returned by TemplateResponse.TemplateID.
+// https://docs.airship.com/api/ua/#operation-api-templates-post
+func (s *TemplateService) Create(ctx context.Context, template Template) (*TemplateResponse, error) {
+	var resp TemplateResponse
+	if err := s.client.Do(ctx, http.MethodPost, EndpointTemplates, newTemplateBody(template), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Get looks up a template by ID.
+// https://docs.airship.com/api/ua/#operation-api-templates-template_id-get
+func (s *TemplateService) Get(ctx context.Context, templateID string) (*Template, error) {
+	var resp templateResponse
+	if err := s.client.Do(ctx, http.MethodGet, templatePath(templateID), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Template, nil
+}
+
+// List returns a Pager over every template in the project.
+// https://docs.airship.com/api/ua/#operation-api-templates-get
+func (s *TemplateService) List() *Pager[Template] {
+	return newPager[Template](s.client, EndpointTemplates, "templates")
+}
+
+// Update replaces a template's name, description, variables and push.
+// https://docs.airship.com/api/ua/#operation-api-templates-template_id-post
+func (s *TemplateService) Update(ctx context.Context, templateID string, template Template) (*TemplateResponse, error) {
+	var resp TemplateResponse
+	if err := s.client.Do(ctx, http.MethodPost, templatePath(templateID), newTemplateBody(template), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Delete deletes a template.
+// https://docs.airship.com/api/ua/#operation-api-templates-template_id-delete
+func (s *TemplateService) Delete(ctx context.Context, templateID string) error {
+	return s.client.Do(ctx, http.MethodDelete, templatePath(templateID), nil, nil)
+}
+
+// Preview renders a template with substitutions, returning the push it would send.
+// https://docs.airship.com/api/ua/#operation-api-templates-template_id-preview-post
+func (s *TemplateService) Preview(ctx context.Context, templateID string, substitutions map[string]string) (*PushObject, error) {
+	if substitutions == nil {
+		substitutions = map[string]string{}
+	}
+	body := templatePreviewRequest{Substitutions: substitutions}
+	var resp templatePreviewResponse
+	if err := s.client.Do(ctx, http.MethodPost, templatePath(templateID)+"/preview", &body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Preview, nil
+}
+
+// Sync makes the project's templates match templates, matching them by Name: existing templates are updated
+// and missing ones created. Templates that aren't in templates are left alone. It returns the IDs of the
+// templates by name. Each name may only be given once. If a request fails, the IDs of the templates synced before
+// it are returned along with the error.
+// This lets templates kept in version control be deployed, for example:
+//    ids, err := templates.Sync(ctx, loadTemplates("templates/")...)
+func (s *TemplateService) Sync(ctx context.Context, templates ...Template) (map[string]string, error) {
+	names := make(map[string]bool, len(templates))
+	for _, template := range templates {
+		if err := template.Validate(); err != nil {
+			return nil, err
+		}
+		if names[template.Name] {
+			return nil, fmt.Errorf("airship: template %q given more than once", template.Name)
+		}
+		names[template.Name] = true
+	}
+	ids := map[string]string{}
+	pager := s.List()
+	for pager.Next(ctx) {
+		ids[pager.Item().Name] = pager.Item().ID
+	}
+	if err := pager.Err(); err != nil {
+		return nil, err
+	}
+
+	synced := make(map[string]string, len(templates))
+	for _, template := range templates {
+		if id, ok := ids[template.Name]; ok {
+			if _, err := s.Update(ctx, id, template); err != nil {
+				return synced, fmt.Errorf("airship: updating template %q: %w", template.Name, err)
+			}
+			synced[template.Name] = id
+			continue
+		}
+		resp, err := s.Create(ctx, template)
+		if err != nil {
+			return synced, fmt.Errorf("airship: creating template %q: %w", template.Name, err)
+		}
+		synced[template.Name] = resp.TemplateID()
+	}
+	return synced, nil
+}
+
+func templatePath(templateID string) string {
+	return EndpointTemplates + "/" + url.PathEscape(templateID)
+}
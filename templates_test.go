@@ -0,0 +1,201 @@
+package airship
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sean-rn/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var welcomeTemplate = Template{
+	Name:        "Welcome",
+	Description: "Sent on sign up",
+	Variables:   []TemplateVariable{{Key: "FIRST_NAME", FriendlyName: "First Name", DefaultValue: "there"}},
+	Push:        TemplatePush{Notification: NotificationObject{Alert: "Hello {{FIRST_NAME}}!"}},
+}
+
+const welcomeTemplateJSON = `{
+	"name": "Welcome",
+	"description": "Sent on sign up",
+	"variables": [{"key": "FIRST_NAME", "friendly_name": "First Name", "default_value": "there"}],
+	"push": {"notification": {"alert": "Hello {{FIRST_NAME}}!"}}
+}`
+
+func TestTemplateService_Create(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "POST", req.Method)
+		assert.Equal(t, "https://go.urbanairship.com/api/templates", req.URL.String())
+		assertBodyJSONEqual(t, welcomeTemplateJSON, req.Body)
+		rw.WriteHeader(http.StatusCreated)
+		rw.Write([]byte(`{"ok": true, "operation_id": "op-1", "template_url": "https://go.urbanairship.com/api/templates/tmpl-1"}`))
+	})
+	service := NewTemplateService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	template := welcomeTemplate
+	template.ID = "ignored"
+	template.CreatedAt = Timestamp{time.Now()}
+	resp, err := service.Create(context.Background(), template)
+	require.Nil(t, err)
+	assert.Equal(t, "tmpl-1", resp.TemplateID())
+	assert.Equal(t, "op-1", resp.OperationID)
+}
+
+func TestTemplateService_Create_Validates(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		t.Error("no request should have been sent")
+	})
+	service := NewTemplateService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	_, err := service.Create(context.Background(), Template{Variables: []TemplateVariable{{FriendlyName: "Name"}}})
+	assert.EqualError(t, err, "airship: invalid payload: missing required value on name; missing required value on variables[0].key")
+
+	template := welcomeTemplate
+	template.Push.Notification.IOS = &IOSOverrideWithTemplate{Template: &TemplateRef{TemplateID: templateIDA, Fields: &TemplateFields{Title: "Hi"}}}
+	_, err = service.Create(context.Background(), template)
+	assert.EqualError(t, err, "airship: invalid payload: both TemplateID and Fields set on push.notification.ios.template")
+}
+
+func TestTemplateService_Get(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "GET", req.Method)
+		assert.Equal(t, "https://go.urbanairship.com/api/templates/tmpl-1", req.URL.String())
+		rw.Write([]byte(`{"ok": true, "template": {
+			"id": "tmpl-1",
+			"name": "Welcome",
+			"description": "Sent on sign up",
+			"variables": [{"key": "FIRST_NAME", "friendly_name": "First Name", "default_value": "there"}],
+			"push": {"notification": {"alert": "Hello {{FIRST_NAME}}!"}},
+			"created_at": "2021-04-02T16:00:00Z",
+			"modified_at": "2021-04-03T16:00:00Z",
+			"last_used": null
+		}}`))
+	})
+	service := NewTemplateService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	template, err := service.Get(context.Background(), "tmpl-1")
+	require.Nil(t, err)
+	expected := welcomeTemplate
+	expected.ID = "tmpl-1"
+	expected.CreatedAt = Timestamp{time.Date(2021, 4, 2, 16, 0, 0, 0, time.UTC)}
+	expected.ModifiedAt = Timestamp{time.Date(2021, 4, 3, 16, 0, 0, 0, time.UTC)}
+	assert.Equal(t, &expected, template)
+}
+
+func TestTemplateService_UpdateDeletePreview(t *testing.T) {
+	ctx := context.Background()
+	testCases := []struct {
+		name         string
+		method       string
+		url          string
+		expectedBody string
+		response     string
+		invoke       func(s *TemplateService) error
+	}{
+		{
+			name:         "update",
+			method:       "POST",
+			url:          "https://go.urbanairship.com/api/templates/tmpl-1",
+			expectedBody: welcomeTemplateJSON,
+			response:     `{"ok": true, "operation_id": "op-2"}`,
+			invoke: func(s *TemplateService) error {
+				_, err := s.Update(ctx, "tmpl-1", welcomeTemplate)
+				return err
+			},
+		},
+		{
+			name:   "delete",
+			method: "DELETE",
+			url:    "https://go.urbanairship.com/api/templates/tmpl-1",
+			invoke: func(s *TemplateService) error { return s.Delete(ctx, "tmpl-1") },
+		},
+		{
+			name:         "preview",
+			method:       "POST",
+			url:          "https://go.urbanairship.com/api/templates/tmpl-1/preview",
+			expectedBody: `{"substitutions": {"FIRST_NAME": "Testy"}}`,
+			response:     `{"ok": true, "preview": {"notification": {"alert": "Hello Testy!"}}}`,
+			invoke: func(s *TemplateService) error {
+				push, err := s.Preview(ctx, "tmpl-1", map[string]string{"FIRST_NAME": "Testy"})
+				if err == nil {
+					assert.Equal(t, "Hello Testy!", push.Notification.Alert)
+				}
+				return err
+			},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, tt.method, req.Method)
+				assert.Equal(t, tt.url, req.URL.String())
+				if tt.expectedBody != "" {
+					assertBodyJSONEqual(t, tt.expectedBody, req.Body)
+				}
+				rw.Write([]byte(tt.response))
+			})
+			service := NewTemplateService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+			assert.Nil(t, tt.invoke(service))
+		})
+	}
+}
+
+func TestTemplateService_Sync(t *testing.T) {
+	var requests []string
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.Method+" "+req.URL.RequestURI())
+		switch req.URL.RequestURI() {
+		case "/api/templates":
+			if req.Method == http.MethodGet {
+				rw.Write([]byte(`{"ok": true, "templates": [{"id": "tmpl-1", "name": "Welcome"}, {"id": "tmpl-2", "name": "Old"}]}`))
+				return
+			}
+			rw.Write([]byte(`{"ok": true, "template_url": "https://go.urbanairship.com/api/templates/tmpl-3"}`))
+		default:
+			rw.Write([]byte(`{"ok": true}`))
+		}
+	})
+	service := NewTemplateService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	goodbye := welcomeTemplate
+	goodbye.Name = "Goodbye"
+	ids, err := service.Sync(context.Background(), welcomeTemplate, goodbye)
+	require.Nil(t, err)
+	assert.Equal(t, map[string]string{"Welcome": "tmpl-1", "Goodbye": "tmpl-3"}, ids)
+	assert.Equal(t, []string{"GET /api/templates", "POST /api/templates/tmpl-1", "POST /api/templates"}, requests)
+}
+
+func TestTemplateService_Sync_DuplicateName(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		t.Error("no request should have been sent")
+	})
+	service := NewTemplateService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	_, err := service.Sync(context.Background(), welcomeTemplate, welcomeTemplate)
+	assert.EqualError(t, err, `airship: template "Welcome" given more than once`)
+}
+
+// On a failed request, the templates already synced are returned with the error.
+func TestTemplateService_Sync_PartialFailure(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == http.MethodGet:
+			rw.Write([]byte(`{"ok": true, "templates": [{"id": "tmpl-1", "name": "Welcome"}]}`))
+		case req.URL.Path == "/api/templates/tmpl-1":
+			rw.Write([]byte(`{"ok": true}`))
+		default:
+			rw.WriteHeader(http.StatusBadRequest)
+			rw.Write([]byte(`{"ok": false, "error": "Invalid template", "error_code": 40001}`))
+		}
+	})
+	service := NewTemplateService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+
+	goodbye := welcomeTemplate
+	goodbye.Name = "Goodbye"
+	ids, err := service.Sync(context.Background(), welcomeTemplate, goodbye)
+	assert.True(t, IsValidationError(err))
+	assert.Equal(t, map[string]string{"Welcome": "tmpl-1"}, ids)
+}
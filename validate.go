@@ -0,0 +1,275 @@
+package airship
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldError describes one field of a payload that failed client-side validation.
+type FieldError struct {
+	Path    string // JSON path of the field, e.g. "notification.ios.template", or "" for the whole payload
+	Message string
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s on %s", e.Message, e.Path)
+}
+
+// ValidationErrors is returned by the Validate methods of payloads, listing every problem found.
+// The Client validates payloads before sending them, so it may be returned by any request.
+type ValidationErrors []*FieldError
+
+// Error implements the error interface.
+func (errs ValidationErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return "airship: invalid payload: " + strings.Join(msgs, "; ")
+}
+
+// validatable is implemented by payloads that can be checked before they are sent.
+type validatable interface {
+	Validate() error
+}
+
+// fieldValidator collects the errors found while walking a payload.
+type fieldValidator struct {
+	errs ValidationErrors
+}
+
+func (v *fieldValidator) fail(path, message string) {
+	v.errs = append(v.errs, &FieldError{Path: path, Message: message})
+}
+
+// nested adds the errors of a nested payload's Validate method, with prefix added to their paths.
+func (v *fieldValidator) nested(prefix string, err error) {
+	if err == nil {
+		return
+	}
+	fieldErrs, ok := err.(ValidationErrors)
+	if !ok {
+		v.fail(prefix, err.Error())
+		return
+	}
+	for _, fieldErr := range fieldErrs {
+		if fieldErr.Path == "" {
+			v.fail(strings.TrimSuffix(prefix, "."), fieldErr.Message)
+			continue
+		}
+		v.fail(prefix+fieldErr.Path, fieldErr.Message)
+	}
+}
+
+func (v *fieldValidator) required(path string, present bool) {
+	if !present {
+		v.fail(path, "missing required value")
+	}
+}
+
+// err returns the collected errors, or nil if there are none.
+func (v *fieldValidator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+// ValidateAudience checks that at least one channel or named user is selected, and that none is empty.
+func (a AudienceSelector) ValidateAudience() error {
+	v := fieldValidator{}
+	v.audienceSelector("audience", &a)
+	return v.err()
+}
+
+// validateBody validates a request body with a Validate method, or each element of a slice of payloads, with the
+// element's index added to the paths of its errors. A nil pointer to a payload is an error, since its Validate
+// method can't be called.
+func validateBody(body interface{}) error {
+	switch b := body.(type) {
+	case validatable:
+		if v := reflect.ValueOf(b); v.Kind() == reflect.Ptr && v.IsNil() {
+			return fmt.Errorf("airship: nil %T payload", body)
+		}
+		return b.Validate()
+	case []PushObject:
+		return validateEach(b)
+	case []PushTemplatePayload:
+		return validateEach(b)
+	case []Schedule:
+		return validateEach(b)
+	}
+	return nil
+}
+
+func validateEach[T validatable](payloads []T) error {
+	v := fieldValidator{}
+	for i, payload := range payloads {
+		v.nested(fmt.Sprintf("[%d].", i), payload.Validate())
+	}
+	return v.err()
+}
+
+// Validate checks that the audience, device types and template ID are set.
+func (p PushTemplatePayload) Validate() error {
+	v := fieldValidator{}
+	v.audience("audience", p.Audience)
+	v.required("device_types", len(p.DeviceTypes) > 0)
+	v.required("merge_data.template_id", p.MergeData.TemplateID != "")
+	return v.err()
+}
+
+// Validate checks that the audience and device types are set, that each listed device type has an override or
+// the notification's alert (email always needs an override), and that the notification's overrides are valid,
+// including that no template sets both a template ID and fields.
+func (p PushObject) Validate() error {
+	v := fieldValidator{}
+	v.audience("audience", p.Audience)
+	v.deviceTypes("device_types", p.DeviceTypes)
+	if deviceTypes, ok := p.DeviceTypes.([]DeviceType); ok {
+		v.overridesFor("notification", deviceTypes, &p.Notification)
+	}
+	v.notification("notification", &p.Notification)
+	return v.err()
+}
+
+// Validate checks that the targets and device types are set, and checks the notification the same way as
+// PushObject.Validate.
+func (p CreateAndSend) Validate() error {
+	v := fieldValidator{}
+	v.required("audience.create_and_send", len(p.Audience.CreateAndSend) > 0)
+	v.required("device_types", len(p.DeviceTypes) > 0)
+	v.notification("notification", &p.Notification)
+	return v.err()
+}
+
+// Validate checks that exactly one send time is set, and checks the schedule's push the same way as
+// PushObject.Validate.
+func (s Schedule) Validate() error {
+	v := fieldValidator{}
+	v.scheduleSpec("schedule", s.Schedule)
+	v.nested("push.", s.Push.Validate())
+	return v.err()
+}
+
+func (v *fieldValidator) scheduleSpec(path string, spec ScheduleSpec) {
+	times := 0
+	for _, set := range []bool{spec.ScheduledTime != "", spec.LocalScheduledTime != "", spec.BestTime != nil} {
+		if set {
+			times++
+		}
+	}
+	switch {
+	case times == 0:
+		v.required(path, false)
+	case times > 1:
+		v.fail(path, "more than one of scheduled_time, local_scheduled_time and best_time set")
+	}
+	if spec.Recurring != nil && spec.ScheduledTime == "" {
+		v.fail(path+".recurring", "only allowed with scheduled_time")
+	}
+}
+
+func (v *fieldValidator) audience(path string, audience Audience) {
+	switch a := audience.(type) {
+	case nil:
+		v.required(path, false)
+	case AudienceSelector:
+		v.audienceSelector(path, &a)
+	case *AudienceSelector:
+		v.required(path, a != nil)
+		if a != nil {
+			v.audienceSelector(path, a)
+		}
+	default:
+		if err := a.ValidateAudience(); err != nil {
+			v.fail(path, err.Error())
+		}
+	}
+}
+
+func (v *fieldValidator) audienceSelector(path string, a *AudienceSelector) {
+	v.required(path, len(a.Channels) > 0 || len(a.NamedUsers) > 0)
+	for i, id := range a.Channels {
+		v.required(fmt.Sprintf("%s.channel[%d]", path, i), id != "")
+	}
+	for i, id := range a.NamedUsers {
+		v.required(fmt.Sprintf("%s.named_user[%d]", path, i), id != "")
+	}
+}
+
+func (v *fieldValidator) deviceTypes(path string, deviceTypes interface{}) {
+	switch d := deviceTypes.(type) {
+	case string:
+		v.required(path, d != "")
+	case []string:
+		v.required(path, len(d) > 0)
+	case []DeviceType:
+		v.required(path, len(d) > 0)
+	default:
+		v.required(path, d != nil)
+	}
+}
+
+// overridesFor checks that the notification has something to send to each of deviceTypes.
+func (v *fieldValidator) overridesFor(path string, deviceTypes []DeviceType, n *NotificationObject) {
+	present := map[DeviceType]bool{}
+	for _, deviceType := range n.deviceTypes() {
+		present[deviceType] = true
+	}
+	for _, deviceType := range deviceTypes {
+		switch {
+		case present[deviceType]:
+		case deviceType == DeviceTypeEmail:
+			v.required(path+".email", false)
+		case n.Alert == "":
+			v.fail(path+"."+string(deviceType), "missing override, and no alert to send instead")
+		}
+	}
+}
+
+func (v *fieldValidator) notification(path string, n *NotificationObject) {
+	v.actions(path+".actions", n.Actions)
+	if n.Android != nil {
+		v.androidOverride(path+".android", n.Android)
+	}
+	if n.IOS != nil {
+		v.iosOverride(path+".ios", n.IOS)
+	}
+	if n.Sms != nil {
+		v.templateRef(path+".sms.template", n.Sms.Template)
+	}
+	if n.Amazon != nil {
+		v.templateRef(path+".amazon.template", n.Amazon.Template)
+		v.actions(path+".amazon.actions", n.Amazon.Actions)
+	}
+	if n.Web != nil {
+		v.templateRef(path+".web.template", n.Web.Template)
+		v.actions(path+".web.actions", n.Web.Actions)
+	}
+	if n.Email != nil {
+		v.emailOverride(path+".email", n.Email)
+	}
+	for platform, open := range n.Open {
+		if open != nil {
+			v.templateRef(path+"."+string(OpenDeviceType(platform))+".template", open.Template)
+		}
+	}
+}
+
+func (v *fieldValidator) templateRef(path string, ref *TemplateRef) {
+	if ref != nil && ref.TemplateID != "" && hasTemplateFields(ref.Fields) {
+		v.fail(path, "both TemplateID and Fields set")
+	}
+}
+
+func (v *fieldValidator) actions(path string, actions *Actions) {
+	if actions != nil && actions.Open != nil {
+		v.required(path+".open.type", actions.Open.Type != "")
+	}
+}
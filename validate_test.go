@@ -0,0 +1,206 @@
+package airship
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sean-rn/go-airship/audience"
+	"github.com/sean-rn/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushObject_Validate(t *testing.T) {
+	withBothTemplates := MakeSendPushPayload(templateIDA, []string{channelA}, nil)
+	withBothTemplates.Notification.IOS.Template.Fields = &TemplateFields{Alert: "Hello"}
+
+	withUntypedAction := MakeSendPushPayload(templateIDA, []string{channelA}, nil)
+	withUntypedAction.Notification.Actions = &Actions{Open: &uaOpenAction{Content: "https://xkcd.com"}}
+
+	testCases := []struct {
+		name     string
+		input    PushObject
+		expected string
+	}{
+		{name: "valid", input: MakeSendPushPayload(templateIDA, []string{channelA}, nil)},
+		{name: "valid broadcast", input: PushObject{Audience: audience.All(), DeviceTypes: "all", Notification: NotificationObject{Alert: "Hello"}}},
+		{
+			name:     "template id and fields",
+			input:    withBothTemplates,
+			expected: "airship: invalid payload: both TemplateID and Fields set on notification.ios.template",
+		},
+		{
+			name:     "open action without type",
+			input:    withUntypedAction,
+			expected: "airship: invalid payload: missing required value on notification.actions.open.type",
+		},
+		{
+			name:     "no audience or device types",
+			input:    MakeSendPushPayload(templateIDA, nil, nil),
+			expected: "airship: invalid payload: missing required value on audience",
+		},
+		{
+			name:     "empty",
+			input:    PushObject{},
+			expected: "airship: invalid payload: missing required value on audience; missing required value on device_types",
+		},
+		{
+			name:     "empty channel ID",
+			input:    MakeSendPushPayload(templateIDA, []string{""}, nil),
+			expected: "airship: invalid payload: missing required value on audience.channel[0]",
+		},
+		{
+			name:     "nil audience selector",
+			input:    PushObject{Audience: (*AudienceSelector)(nil), DeviceTypes: "all", Notification: NotificationObject{Alert: "Hello"}},
+			expected: "airship: invalid payload: missing required value on audience",
+		},
+		{
+			name:     "selector without IDs",
+			input:    PushObject{Audience: audience.Channel(), DeviceTypes: "all", Notification: NotificationObject{Alert: "Hello"}},
+			expected: "airship: invalid payload: empty channel selector on audience",
+		},
+		{
+			name:     "compound selector",
+			input:    PushObject{Audience: audience.And(audience.Tag("vip", ""), audience.Segment("")), DeviceTypes: "all"},
+			expected: "airship: invalid payload: empty segment selector on audience",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if tt.expected == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expected)
+				assert.True(t, IsValidationError(err))
+			}
+		})
+	}
+}
+
+func TestPushTemplatePayload_Validate(t *testing.T) {
+	assert.Nil(t, MakePushTemplatePayload(templateIDA, []string{channelA}, nil).Validate())
+
+	err := MakePushTemplatePayload("", []string{channelA}, nil).Validate()
+	var fieldErrs ValidationErrors
+	require.True(t, errors.As(err, &fieldErrs))
+	assert.Equal(t, ValidationErrors{{Path: "merge_data.template_id", Message: "missing required value"}}, fieldErrs)
+}
+
+func TestCreateAndSend_Validate(t *testing.T) {
+	payload, err := MakeCreateAndSendSMSPayload(templateIDA, nil, true, []CreateAndSendSMSTarget{{MSISDN: "19785551212", Sender: "12062071886"}})
+	require.Nil(t, err)
+	assert.Nil(t, payload.Validate())
+
+	payload.Notification.Sms.Template.Fields = &TemplateFields{Alert: "Hello"}
+	payload.Audience.CreateAndSend = nil
+	assert.EqualError(t, payload.Validate(), "airship: invalid payload: missing required value on audience.create_and_send; both TemplateID and Fields set on notification.sms.template")
+}
+
+func TestSchedule_Validate(t *testing.T) {
+	schedule := Schedule{Push: MakeSendPushPayload(templateIDA, nil, nil)}
+	assert.EqualError(t, schedule.Validate(), "airship: invalid payload: missing required value on schedule; missing required value on push.audience")
+
+	at := time.Date(2021, 4, 2, 16, 0, 0, 0, time.UTC)
+	bothTimes := ScheduleAt(at)
+	bothTimes.BestTime = ScheduleAtBestTime(at).BestTime
+	recurringLocal := ScheduleAtLocalTime(at)
+	recurringLocal.Recurring = ScheduleRecurring(at, Cadence{Type: CadenceDaily, Count: 1}, time.Time{}).Recurring
+
+	testCases := []struct {
+		name     string
+		input    ScheduleSpec
+		expected string
+	}{
+		{name: "scheduled time", input: ScheduleAt(at)},
+		{name: "local time", input: ScheduleAtLocalTime(at)},
+		{name: "best time", input: ScheduleAtBestTime(at)},
+		{name: "recurring", input: ScheduleRecurring(at, Cadence{Type: CadenceDaily, Count: 1}, time.Time{})},
+		{
+			name:     "two times",
+			input:    bothTimes,
+			expected: "airship: invalid payload: more than one of scheduled_time, local_scheduled_time and best_time set on schedule",
+		},
+		{
+			name:     "recurring local time",
+			input:    recurringLocal,
+			expected: "airship: invalid payload: only allowed with scheduled_time on schedule.recurring",
+		},
+		{
+			name:     "recurring only",
+			input:    ScheduleSpec{Recurring: recurringLocal.Recurring},
+			expected: "airship: invalid payload: missing required value on schedule; only allowed with scheduled_time on schedule.recurring",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Schedule{Schedule: tt.input, Push: MakeSendPushPayload(templateIDA, []string{channelA}, nil)}.Validate()
+			if tt.expected == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expected)
+			}
+		})
+	}
+}
+
+// Invalid payloads are rejected by the Client without a round trip.
+func TestInvokeEndpoint_ValidatesPayload(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		t.Error("no request should have been sent")
+	})
+
+	testConnection := New(WithHTTPClient(client), WithBearerAuth(TestBearerToken))
+
+	payload := MakeSendPushPayload(templateIDA, []string{channelA}, nil)
+	payload.Notification.Android.Template.Fields = &TemplateFields{Title: "Hi"}
+	err := testConnection.InvokeEndpoint(http.MethodPost, EndpointSendPush, &payload)
+	assert.EqualError(t, err, "airship: invalid payload: both TemplateID and Fields set on notification.android.template")
+}
+
+// Each payload of a batch is validated, with its index in the error paths.
+func TestPushService_ValidatePush_ValidatesEachPayload(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		t.Error("no request should have been sent")
+	})
+	service := NewPushService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+	ctx := context.Background()
+
+	err := service.ValidatePush(ctx, []PushObject{MakeSendPushPayload(templateIDA, []string{channelA}, nil), {}})
+	assert.EqualError(t, err, "airship: invalid payload: missing required value on [1].audience; missing required value on [1].device_types")
+	assert.True(t, IsValidationError(err))
+
+	err = service.ValidatePush(ctx, []PushTemplatePayload{MakePushTemplatePayload("", []string{channelA}, nil)})
+	assert.EqualError(t, err, "airship: invalid payload: missing required value on [0].merge_data.template_id")
+}
+
+// Nil payload pointers are reported rather than calling their Validate methods.
+func TestPushService_NilPayload(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		t.Error("no request should have been sent")
+	})
+	service := NewPushService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+	ctx := context.Background()
+
+	_, err := service.CreateAndSend(ctx, nil)
+	assert.EqualError(t, err, "airship: nil *airship.CreateAndSend payload")
+	err = service.ValidatePush(ctx, (*PushObject)(nil))
+	assert.EqualError(t, err, "airship: nil *airship.PushObject payload")
+}
+
+func TestScheduleService_Create_ValidatesEachSchedule(t *testing.T) {
+	client := httpmock.NewHandlerClient(func(rw http.ResponseWriter, req *http.Request) {
+		t.Error("no request should have been sent")
+	})
+	service := NewScheduleService(New(WithHTTPClient(client), WithBearerAuth(TestBearerToken)))
+	valid := Schedule{
+		Schedule: ScheduleAt(time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)),
+		Push:     MakeSendPushPayload(templateIDA, []string{channelA}, nil),
+	}
+
+	_, err := service.Create(context.Background(), valid, Schedule{Push: valid.Push})
+	assert.EqualError(t, err, "airship: invalid payload: missing required value on [1].schedule")
+}